@@ -3,14 +3,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"sort"
+	"strings"
 
 	"github.com/jack-sneddon/backup-butler/internal/backup"
 	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/version"
 )
 
 const (
@@ -26,6 +29,27 @@ func main() {
 	showVersions := flag.Bool("versions", false, "Show backup version history")
 	verifyIntegrity := flag.Bool("verify", false, "Verify integrity of backed up files")
 	showIssues := flag.Bool("show-issues", false, "Show detected integrity issues")
+	prune := flag.Bool("prune", false, "Prune backup version history")
+	pruneKeepLast := flag.Int("keep-last", 0, "Prune: keep the N most recent versions")
+	pruneKeepWithin := flag.String("keep-within", "", "Prune: keep versions newer than this duration (e.g. 30d, 2w, 72h)")
+	pruneKeepStorage := flag.Int64("keep-storage", 0, "Prune: delete oldest versions until the journal footprint is under this many bytes")
+	pruneKeepHourly := flag.Int("keep-hourly", 0, "Prune: keep the newest version for each of the last N hourly buckets")
+	pruneKeepDaily := flag.Int("keep-daily", 0, "Prune: keep the newest version for each of the last N daily buckets")
+	pruneKeepWeekly := flag.Int("keep-weekly", 0, "Prune: keep the newest version for each of the last N ISO-weekly buckets")
+	pruneKeepMonthly := flag.Int("keep-monthly", 0, "Prune: keep the newest version for each of the last N monthly buckets")
+	pruneKeepYearly := flag.Int("keep-yearly", 0, "Prune: keep the newest version for each of the last N yearly buckets")
+	pruneKeepTags := flag.String("keep-tags", "", "Prune: comma-separated tags; versions carrying any of them are always kept")
+	pruneDryRun := flag.Bool("prune-dry-run", false, "Prune: report what would be removed without deleting anything")
+	pruneJSON := flag.Bool("json", false, "Prune: emit the prune report as JSON")
+	filesFrom := flag.String("files-from", "", "Read the list of files to back up from this file (one path per line, '-' for stdin), instead of folders_to_backup")
+	useStdin := flag.Bool("stdin", false, "Back up a single file streamed from stdin instead of walking folders_to_backup")
+	stdinFilename := flag.String("stdin-filename", "", "Destination filename for --stdin content")
+	deepVerify := flag.String("deep-verify", "", "Glob pattern (e.g. '**/*.raw') of files to verify with a full SHA-256 hash instead of a 32KB probe")
+	resume := flag.String("resume", "", "Resume an interrupted backup version, replaying its session journal to skip completed tasks")
+	abandonSession := flag.String("abandon-session", "", "Discard an interrupted version's session journal without finalizing it")
+	restoreVersion := flag.String("restore", "", "Reassemble a file from a backup version's chunk manifest (requires -restore-path and -restore-output)")
+	restorePath := flag.String("restore-path", "", "Source-relative path of the file to restore")
+	restoreOutput := flag.String("restore-output", "", "Where to write the restored file")
 
 	flag.Parse()
 
@@ -41,6 +65,25 @@ func main() {
 		os.Exit(ExitError)
 	}
 
+	if *useStdin {
+		if *stdinFilename == "" {
+			fmt.Println("Error: -stdin requires -stdin-filename")
+			os.Exit(ExitError)
+		}
+		cfg.StdinFilename = *stdinFilename
+	} else if *filesFrom != "" {
+		cfg.FilesFrom = *filesFrom
+	}
+
+	if *deepVerify != "" {
+		cfg.DeepVerifyPatterns = append(cfg.DeepVerifyPatterns, *deepVerify)
+	}
+
+	if len(cfg.FoldersToBackup) == 0 && cfg.FilesFrom == "" && cfg.StdinFilename == "" {
+		fmt.Println("Error: folders_to_backup must contain at least one folder, or pass -files-from/-stdin")
+		os.Exit(ExitError)
+	}
+
 	// Add version display before other operations
 	if *showVersions {
 		if err := displayVersionHistory(cfg); err != nil {
@@ -87,7 +130,59 @@ func main() {
 		os.Exit(ExitSuccess)
 	}
 
-	if err := runBackup(cfg); err != nil {
+	if *restoreVersion != "" {
+		if *restorePath == "" || *restoreOutput == "" {
+			fmt.Println("Error: -restore requires -restore-path and -restore-output")
+			os.Exit(ExitError)
+		}
+		if err := runRestore(cfg, *restoreVersion, *restorePath, *restoreOutput); err != nil {
+			fmt.Printf("Restore failed: %v\n", err)
+			os.Exit(ExitError)
+		}
+		os.Exit(ExitSuccess)
+	}
+
+	if *abandonSession != "" {
+		if err := runAbandonSession(cfg, *abandonSession); err != nil {
+			fmt.Printf("Failed to abandon session: %v\n", err)
+			os.Exit(ExitError)
+		}
+		os.Exit(ExitSuccess)
+	}
+
+	if *prune {
+		keepWithin, err := version.ParseKeepWithin(*pruneKeepWithin)
+		if err != nil {
+			fmt.Printf("Invalid -keep-within value: %v\n", err)
+			os.Exit(ExitError)
+		}
+
+		var keepTags []string
+		if *pruneKeepTags != "" {
+			keepTags = strings.Split(*pruneKeepTags, ",")
+		}
+
+		opts := version.PruneOptions{
+			KeepLast:    *pruneKeepLast,
+			KeepWithin:  keepWithin,
+			KeepStorage: *pruneKeepStorage,
+			KeepHourly:  *pruneKeepHourly,
+			KeepDaily:   *pruneKeepDaily,
+			KeepWeekly:  *pruneKeepWeekly,
+			KeepMonthly: *pruneKeepMonthly,
+			KeepYearly:  *pruneKeepYearly,
+			KeepTags:    keepTags,
+			DryRun:      *pruneDryRun,
+		}
+
+		if err := runPrune(cfg, opts, *pruneJSON); err != nil {
+			fmt.Printf("Prune failed: %v\n", err)
+			os.Exit(ExitError)
+		}
+		os.Exit(ExitSuccess)
+	}
+
+	if err := runBackup(cfg, *resume); err != nil {
 		fmt.Printf("Backup failed: %v\n", err)
 		os.Exit(ExitError)
 	}
@@ -171,7 +266,7 @@ func runFeatureTests(cfg *config.Config) error {
 }
 */
 
-func runBackup(cfg *config.Config) error {
+func runBackup(cfg *config.Config, resumeVersion string) error {
 	fmt.Println("\nInitializing backup service...")
 
 	service, err := backup.NewService(cfg)
@@ -191,10 +286,90 @@ func runBackup(cfg *config.Config) error {
 		cancel()
 	}()
 
+	if resumeVersion == "" {
+		found, err := backup.FindIncompleteSession(cfg.TargetDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to check for an incomplete session: %w", err)
+		}
+		resumeVersion = found
+	}
+
+	if resumeVersion != "" {
+		return service.Resume(ctx, resumeVersion)
+	}
+
 	fmt.Println("\nStarting backup operation...")
 	return service.Backup(ctx)
 }
 
+func runRestore(cfg *config.Config, versionID, relPath, outputPath string) error {
+	service, err := backup.NewService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize service: %w", err)
+	}
+
+	if err := service.RestoreFile(versionID, relPath, outputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %s (version %s) to %s\n", relPath, versionID, outputPath)
+	return nil
+}
+
+func runAbandonSession(cfg *config.Config, versionID string) error {
+	service, err := backup.NewService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize service: %w", err)
+	}
+
+	if err := service.AbandonSession(versionID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Abandoned session %s\n", versionID)
+	return nil
+}
+
+func runPrune(cfg *config.Config, opts version.PruneOptions, asJSON bool) error {
+	service, err := backup.NewService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize service: %w", err)
+	}
+
+	report, err := service.Prune(opts)
+	if err != nil {
+		return fmt.Errorf("failed to prune versions: %w", err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal prune report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if report.DryRun {
+		fmt.Println("\nPrune dry run - no versions were removed:")
+	} else {
+		fmt.Println("\nPrune completed:")
+	}
+
+	for _, v := range report.Versions {
+		verb := "would remove"
+		if v.Removed {
+			verb = "removed"
+		}
+		fmt.Printf("  %s version %s (%s, %.2f MB reclaimed)\n", verb, v.ID, v.Reason, float64(v.ReclaimedBytes)/(1024*1024))
+	}
+
+	fmt.Printf("\nTotal reclaimed: %.2f MB\n", float64(report.TotalReclaimed)/(1024*1024))
+	fmt.Printf("Remaining footprint: %.2f MB\n", float64(report.RemainingBytes)/(1024*1024))
+
+	return nil
+}
+
 func verifyBackupIntegrity(cfg *config.Config) error {
 	service, err := backup.NewService(cfg)
 	if err != nil {
@@ -203,6 +378,14 @@ func verifyBackupIntegrity(cfg *config.Config) error {
 
 	fmt.Println("Verifying backup integrity...")
 
+	for _, pattern := range cfg.DeepVerifyPatterns {
+		sums, err := service.ChecksumWildcard(context.Background(), pattern)
+		if err != nil {
+			return fmt.Errorf("failed to deep-verify pattern %q: %w", pattern, err)
+		}
+		fmt.Printf("Deep-verified %d files matching %q\n", len(sums), pattern)
+	}
+
 	// Use the service to verify integrity
 	issues, err := service.GetIntegrityIssues()
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,6 +51,11 @@ func (l *FileConfigLoader) Load(path string) (*backup.BackupConfig, error) {
 		ExcludePatterns    []string `json:"exclude_patterns" yaml:"exclude_patterns"`
 		ChecksumAlgorithm  string   `json:"checksum_algorithm" yaml:"checksum_algorithm"`
 		LogLevel           string   `json:"log_level" yaml:"log_level"`
+		NormalizeUnicode   bool     `json:"normalize_unicode" yaml:"normalize_unicode"`
+		ChunkedCopy        bool     `json:"chunked_copy" yaml:"chunked_copy"`
+		PackSizeBytes      int      `json:"pack_size_bytes" yaml:"pack_size_bytes"`
+		ChecksumCache      bool     `json:"checksum_cache" yaml:"checksum_cache"`
+		DeltaCopyThreshold int64    `json:"delta_copy_threshold" yaml:"delta_copy_threshold"`
 	}
 
 	// Parse into temporary struct
@@ -81,6 +87,11 @@ func (l *FileConfigLoader) Load(path string) (*backup.BackupConfig, error) {
 		ChecksumAlgorithm:  temp.ChecksumAlgorithm,
 		ExcludePatterns:    temp.ExcludePatterns,
 		LogLevel:           temp.LogLevel,
+		NormalizeUnicode:   temp.NormalizeUnicode,
+		ChunkedCopy:        temp.ChunkedCopy,
+		PackSizeBytes:      temp.PackSizeBytes,
+		ChecksumCache:      temp.ChecksumCache,
+		DeltaCopyThreshold: temp.DeltaCopyThreshold,
 		RetryDelay:         l.defaultRetryDelay, // Default value
 		Options:            &backup.ConfigOptions{},
 	}
@@ -94,6 +105,15 @@ func (l *FileConfigLoader) Load(path string) (*backup.BackupConfig, error) {
 		config.RetryDelay = duration
 	}
 
+	// Overlay BB_* environment variables, the way restic layers
+	// RESTIC_REPOSITORY/RESTIC_HOST onto its config: env wins over the file
+	// value so the tool can run in cron/container environments without
+	// editing config files, but a CLI flag (handled by the caller, above
+	// this loader) still wins over both.
+	if err := applyEnvOverrides(config); err != nil {
+		return nil, err
+	}
+
 	// Set up Options
 	config.Options.LogLevel = config.LogLevel
 
@@ -105,6 +125,72 @@ func (l *FileConfigLoader) Load(path string) (*backup.BackupConfig, error) {
 	return config, nil
 }
 
+// envOverride reads the named environment variable, parses it with parse,
+// and assigns the result into *dst. It's a no-op if the variable isn't set.
+// A parse failure is reported as "invalid NAME=value: ..." so a typo'd
+// override surfaces immediately instead of silently falling back to the
+// file value.
+func envOverride[T any](dst *T, name string, parse func(string) (T, error)) error {
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	val, err := parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s=%q: %w", name, raw, err)
+	}
+
+	*dst = val
+	return nil
+}
+
+func envString(s string) (string, error) { return s, nil }
+
+func envInt(s string) (int, error) { return strconv.Atoi(s) }
+
+func envDuration(s string) (time.Duration, error) { return time.ParseDuration(s) }
+
+func envBool(s string) (bool, error) { return strconv.ParseBool(s) }
+
+func envInt64(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+
+// envStringList splits on ':', matching PATH-style env vars rather than the
+// config file's YAML list, since a comma-separated value can't safely carry
+// glob patterns that themselves contain commas.
+func envStringList(s string) ([]string, error) { return strings.Split(s, ":"), nil }
+
+// applyEnvOverrides overlays the BB_* environment variables onto an
+// already-parsed config, in place. Each override is independent; the first
+// parse error is returned so the caller sees exactly which variable was
+// malformed.
+func applyEnvOverrides(config *backup.BackupConfig) error {
+	overrides := []func() error{
+		func() error { return envOverride(&config.SourceDirectory, "BB_SOURCE_DIRECTORY", envString) },
+		func() error { return envOverride(&config.TargetDirectory, "BB_TARGET_DIRECTORY", envString) },
+		func() error { return envOverride(&config.Concurrency, "BB_CONCURRENCY", envInt) },
+		func() error { return envOverride(&config.BufferSize, "BB_BUFFER_SIZE", envInt) },
+		func() error { return envOverride(&config.ChecksumAlgorithm, "BB_CHECKSUM_ALGORITHM", envString) },
+		func() error { return envOverride(&config.LogLevel, "BB_LOG_LEVEL", envString) },
+		func() error { return envOverride(&config.ExcludePatterns, "BB_EXCLUDE_PATTERNS", envStringList) },
+		func() error { return envOverride(&config.RetryAttempts, "BB_RETRY_ATTEMPTS", envInt) },
+		func() error { return envOverride(&config.RetryDelay, "BB_RETRY_DELAY", envDuration) },
+		func() error { return envOverride(&config.NormalizeUnicode, "BB_NORMALIZE_UNICODE", envBool) },
+		func() error { return envOverride(&config.ChunkedCopy, "BB_CHUNKED_COPY", envBool) },
+		func() error { return envOverride(&config.PackSizeBytes, "BB_PACK_SIZE_BYTES", envInt) },
+		func() error { return envOverride(&config.ChecksumCache, "BB_CHECKSUM_CACHE", envBool) },
+		func() error { return envOverride(&config.DeltaCopyThreshold, "BB_DELTA_COPY_THRESHOLD", envInt64) },
+	}
+
+	for _, override := range overrides {
+		if err := override(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (l *FileConfigLoader) Validate(config *backup.BackupConfig) error {
 	var errors []string
 
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/fs"
 )
 
 type FilesystemLogger struct {
@@ -29,11 +31,12 @@ const (
 func NewFilesystemLogger(basePath string) (*FilesystemLogger, error) {
 	// Create logs directory
 	logDir := filepath.Join(basePath, "logs")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
+	if err := fs.OS().MkdirAll(logDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %v", err)
 	}
 
-	// Create log file with timestamp
+	// Create log file with timestamp. fs.Filesystem has no append-mode
+	// open, so the file itself is still opened directly with os.OpenFile.
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
 	logFile := filepath.Join(logDir, fmt.Sprintf("backup_%s.log", timestamp))
 
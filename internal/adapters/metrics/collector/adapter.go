@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/jack-sneddon/backup-butler/internal/core/monitoring"
+	"github.com/jack-sneddon/backup-butler/internal/core/taskerr"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
 )
 
@@ -12,8 +13,8 @@ type MetricsCollector struct {
 	metrics *monitoring.Metrics
 }
 
-func NewMetricsCollector(totalFiles int, quiet bool) *MetricsCollector {
-	metrics := monitoring.NewMetrics(quiet)
+func NewMetricsCollector(totalFiles int, quiet, jsonMode bool) *MetricsCollector {
+	metrics := monitoring.NewMetrics(quiet, jsonMode)
 	metrics.SetTotalFiles(totalFiles)
 	return &MetricsCollector{
 		metrics: metrics,
@@ -51,3 +52,27 @@ func (m *MetricsCollector) DisplayFinalSummary() {
 func (m *MetricsCollector) SetTotalFiles(total int) {
 	m.metrics.SetTotalFiles(total)
 }
+
+func (m *MetricsCollector) AddToTotal(files int, bytes int64) {
+	m.metrics.AddToTotal(files, bytes)
+}
+
+// RecordError forwards a failed task's classified error to the aggregate
+// end-of-run error report. Not a MetricsPort method - core/backup.Service
+// reaches it through the same optional-capability interface check as
+// SetCacheStats/SetBytesReused, since a fake MetricsPort in a test has
+// nothing to aggregate into.
+func (m *MetricsCollector) RecordError(terr *taskerr.TaskError) {
+	m.metrics.RecordError(terr)
+}
+
+// DisplayErrorSummary prints the human table of this run's recorded errors.
+func (m *MetricsCollector) DisplayErrorSummary(runID string) {
+	m.metrics.DisplayErrorSummary(runID)
+}
+
+// WriteErrorReport saves the run's error summary as
+// <baseDir>/.backup-butler/errors-<runID>.json.
+func (m *MetricsCollector) WriteErrorReport(baseDir, runID string) error {
+	return m.metrics.WriteErrorReport(baseDir, runID)
+}
@@ -3,50 +3,171 @@ package backup
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	corebackup "github.com/jack-sneddon/backup-butler/internal/core/backup"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/lazy"
 )
 
+// supportedChecksumAlgorithms lists the values config.ChecksumAlgorithm is
+// allowed to take. The hexagonal storage path (core/storage.Manager)
+// hashes with SHA-256 unconditionally today - this exists so HealthCheck
+// can still catch a config typo (e.g. "sha-256") before a run, rather than
+// only once a different algorithm is actually wired up.
+var supportedChecksumAlgorithms = map[string]bool{
+	"":       true,
+	"sha256": true,
+}
+
+// BackupServiceAdapter implements backup.BackupService over a
+// corebackup.Service that isn't built until first needed - see
+// app.Factory.CreateBackupService - so a caller that only wants
+// HealthCheck or DryRun doesn't pay for every subsystem's construction.
 type BackupServiceAdapter struct {
-	service *corebackup.Service
+	config     *backup.BackupConfig
+	storage    *lazy.Lazy[backup.StoragePort]
+	versioner  *lazy.Lazy[backup.VersionManagerPort]
+	workerPool *lazy.Lazy[backup.WorkerPoolPort]
+
+	service *lazy.Lazy[*corebackup.Service]
 }
 
 func NewBackupService(
 	config *backup.BackupConfig,
-	storage backup.StoragePort,
-	metrics backup.MetricsPort,
-	versioner backup.VersionManagerPort,
-	taskMgr backup.TaskManagerPort,
-	workerPool backup.WorkerPoolPort,
+	storage *lazy.Lazy[backup.StoragePort],
+	metrics *lazy.Lazy[backup.MetricsPort],
+	versioner *lazy.Lazy[backup.VersionManagerPort],
+	taskMgr *lazy.Lazy[backup.TaskManagerPort],
+	workerPool *lazy.Lazy[backup.WorkerPoolPort],
 ) *BackupServiceAdapter {
-	service := corebackup.NewService(config, storage, metrics, versioner, taskMgr, workerPool)
+	service := lazy.New(func() (*corebackup.Service, error) {
+		storagePort, err := storage.Get()
+		if err != nil {
+			return nil, err
+		}
+		metricsPort, err := metrics.Get()
+		if err != nil {
+			return nil, err
+		}
+		versionerPort, err := versioner.Get()
+		if err != nil {
+			return nil, err
+		}
+		taskMgrPort, err := taskMgr.Get()
+		if err != nil {
+			return nil, err
+		}
+		workerPoolPort, err := workerPool.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		return corebackup.NewService(config, storagePort, metricsPort, versionerPort, taskMgrPort, workerPoolPort), nil
+	})
+
 	return &BackupServiceAdapter{
-		service: service,
+		config:     config,
+		storage:    storage,
+		versioner:  versioner,
+		workerPool: workerPool,
+		service:    service,
 	}
 }
 
-// Backup delegates to the core service
+// Backup delegates to the core service, constructing it (and every
+// component it depends on) on this first call if nothing has yet.
 func (a *BackupServiceAdapter) Backup(ctx context.Context) error {
-	return a.service.Backup(ctx)
+	service, err := a.service.Get()
+	if err != nil {
+		return err
+	}
+	return service.Backup(ctx)
 }
 
 // DryRun delegates to the core service
 func (a *BackupServiceAdapter) DryRun(ctx context.Context) error {
-	return a.service.DryRun(ctx)
+	service, err := a.service.Get()
+	if err != nil {
+		return err
+	}
+	return service.DryRun(ctx)
 }
 
 // GetVersions delegates to the core service
 func (a *BackupServiceAdapter) GetVersions() []backup.BackupVersion {
-	return a.service.GetVersions()
+	service, err := a.service.Get()
+	if err != nil {
+		return nil
+	}
+	return service.GetVersions()
 }
 
 // GetVersion delegates to the core service
 func (a *BackupServiceAdapter) GetVersion(id string) (*backup.BackupVersion, error) {
-	return a.service.GetVersion(id)
+	service, err := a.service.Get()
+	if err != nil {
+		return nil, err
+	}
+	return service.GetVersion(id)
 }
 
 // GetLatestVersion delegates to the core service
 func (a *BackupServiceAdapter) GetLatestVersion() (*backup.BackupVersion, error) {
-	return a.service.GetLatestVersion()
+	service, err := a.service.Get()
+	if err != nil {
+		return nil, err
+	}
+	return service.GetLatestVersion()
+}
+
+// HealthCheck probes every subsystem a backup run depends on without
+// running one: that the target directory exists and is writable, that
+// config.ChecksumAlgorithm names something supported, that the version
+// manifest can be opened and read, and that the worker pool can be built.
+// It's deliberately independent of a.service - a storage backend that
+// can't reach its target shouldn't stop HealthCheck from still reporting
+// on the version manifest and worker pool - and returns every failure
+// together via errors.Join rather than stopping at the first one, so
+// `backup-butler -doctor` (or `-validate`) surfaces the whole picture in
+// one pass.
+func (a *BackupServiceAdapter) HealthCheck(ctx context.Context) error {
+	var errs []error
+
+	if err := checkTargetWritable(a.config.TargetDirectory); err != nil {
+		errs = append(errs, fmt.Errorf("target directory: %w", err))
+	}
+
+	if !supportedChecksumAlgorithms[a.config.ChecksumAlgorithm] {
+		errs = append(errs, fmt.Errorf("checksum algorithm: unsupported value %q", a.config.ChecksumAlgorithm))
+	}
+
+	if _, err := a.versioner.Get(); err != nil {
+		errs = append(errs, fmt.Errorf("version manifest: %w", err))
+	}
+
+	if _, err := a.workerPool.Get(); err != nil {
+		errs = append(errs, fmt.Errorf("worker pool: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkTargetWritable creates config.TargetDirectory if it doesn't exist
+// yet, then writes and removes a probe file inside it, so a target on
+// read-only storage or with a permissions problem is caught here instead
+// of partway through the first real backup task.
+func checkTargetWritable(targetDir string) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", targetDir, err)
+	}
+
+	probe := filepath.Join(targetDir, ".backup-butler-healthcheck")
+	if err := os.WriteFile(probe, []byte("ok"), 0644); err != nil {
+		return fmt.Errorf("cannot write to %s: %w", targetDir, err)
+	}
+	return os.Remove(probe)
 }
@@ -2,7 +2,10 @@
 package filesystem
 
 import (
+	"context"
+
 	"github.com/jack-sneddon/backup-butler/internal/core/storage"
+	"github.com/jack-sneddon/backup-butler/internal/core/storage/cache"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
 )
 
@@ -20,26 +23,85 @@ func NewFilesystemAdapter(checksumAlg string, bufferSize int) *FilesystemAdapter
 }
 
 // Implement backup.StoragePort interface using our core Manager
-func (a *FilesystemAdapter) CalculateChecksum(filePath string) (string, error) {
-	return a.manager.CalculateChecksum(filePath)
+func (a *FilesystemAdapter) CalculateChecksum(ctx context.Context, filePath string) (string, error) {
+	return a.manager.CalculateChecksum(ctx, filePath)
 }
 
-func (a *FilesystemAdapter) Copy(src, dst string, bufferSize int) (int64, error) {
-	return a.manager.Copy(src, dst, bufferSize)
+func (a *FilesystemAdapter) Copy(ctx context.Context, src, dst string, bufferSize int) (int64, error) {
+	return a.manager.Copy(ctx, src, dst, bufferSize)
 }
 
-func (a *FilesystemAdapter) Exists(path string) (bool, error) {
-	return a.manager.Exists(path)
+func (a *FilesystemAdapter) Exists(ctx context.Context, path string) (bool, error) {
+	return a.manager.Exists(ctx, path)
 }
 
-func (a *FilesystemAdapter) GetMetadata(path string) (backup.FileMetadata, error) {
-	return a.manager.GetMetadata(path)
+func (a *FilesystemAdapter) GetMetadata(ctx context.Context, path string) (backup.FileMetadata, error) {
+	return a.manager.GetMetadata(ctx, path)
 }
 
-func (a *FilesystemAdapter) CreateDirectory(path string) error {
-	return a.manager.CreateDirectory(path)
+func (a *FilesystemAdapter) CreateDirectory(ctx context.Context, path string) error {
+	return a.manager.CreateDirectory(ctx, path)
 }
 
 func (a *FilesystemAdapter) IsDirectory(path string) (bool, error) {
 	return a.manager.IsDirectory(path)
 }
+
+func (a *FilesystemAdapter) Rename(oldPath, newPath string) error {
+	return a.manager.Rename(oldPath, newPath)
+}
+
+func (a *FilesystemAdapter) SameLogicalPath(src, dst string) bool {
+	return a.manager.SameLogicalPath(src, dst)
+}
+
+// SetCache attaches a persistent checksum cache to the underlying Manager.
+// Not part of backup.StoragePort - app.Factory calls this directly when
+// config.ChecksumCache is enabled, since the cache is an optional
+// performance knob rather than a capability every StoragePort implementation
+// needs to support.
+func (a *FilesystemAdapter) SetCache(c *cache.Store) {
+	a.manager.SetCache(c)
+}
+
+// CacheStats reports the attached cache's cumulative hit/miss count.
+// core.backup.Service looks this up through an interface check rather than
+// a StoragePort method, for the same reason SetCache isn't one.
+func (a *FilesystemAdapter) CacheStats() (hits, misses int) {
+	return a.manager.CacheStats()
+}
+
+// FlushCache writes the attached cache's current state to disk. A no-op if
+// no cache is attached.
+func (a *FilesystemAdapter) FlushCache() error {
+	return a.manager.FlushCache()
+}
+
+// CopyDelta reuses matching blocks of an existing destination instead of
+// re-transferring the whole file. Not part of backup.StoragePort -
+// task.Manager looks this up through an interface check when
+// config.DeltaCopyThreshold applies, the same way it would for any other
+// optional capability a StoragePort implementation might not offer.
+func (a *FilesystemAdapter) CopyDelta(ctx context.Context, src, dst string) (bytesTransferred, bytesReused int64, err error) {
+	return a.manager.CopyDelta(ctx, src, dst)
+}
+
+// BytesReused reports CopyDelta's cumulative savings across this run.
+// core.backup.Service looks this up through an interface check, the same
+// way it does CacheStats.
+func (a *FilesystemAdapter) BytesReused() int64 {
+	return a.manager.BytesReused()
+}
+
+// HasAtomicReplace reports whether Copy's destination can be swapped into
+// place atomically (write-to-temp-then-rename, same filesystem), so a
+// caller deciding whether it's safe to replace an existing destination
+// in-place - rather than writing alongside it and verifying before
+// removing the original - can check this instead of assuming every
+// backup.StoragePort behaves like a local disk. Not part of StoragePort
+// itself, the same optional-capability pattern as CacheStats/CopyDelta;
+// a remote backend without restic-style atomic rename support would
+// report false here.
+func (a *FilesystemAdapter) HasAtomicReplace() bool {
+	return true
+}
@@ -0,0 +1,20 @@
+// internal/adapters/storage/filesystem/driver.go
+package filesystem
+
+import (
+	"github.com/jack-sneddon/backup-butler/internal/adapters/storage/registry"
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+)
+
+func init() {
+	registry.Register("file", driver{})
+}
+
+// driver opens a FilesystemAdapter for the "file" scheme - also the
+// fallback registry.Open picks for a config.TargetDirectory with no
+// "scheme://" prefix, i.e. every plain local path in use today.
+type driver struct{}
+
+func (driver) Open(target string, opts registry.Options) (backup.StoragePort, error) {
+	return NewFilesystemAdapter(opts.ChecksumAlgorithm, opts.BufferSize), nil
+}
@@ -1,32 +1,36 @@
 package mock
 
 import (
+	"context"
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"golang.org/x/text/unicode/norm"
 )
 
 type MockStorageAdapter struct {
-	CalculateChecksumFunc func(filePath string) (string, error)
-	CopyFunc              func(src, dst string, bufferSize int) (int64, error)
-	ExistsFunc            func(path string) (bool, error)
-	GetMetadataFunc       func(path string) (backup.FileMetadata, error)
-	CreateDirectoryFunc   func(path string) error
+	CalculateChecksumFunc func(ctx context.Context, filePath string) (string, error)
+	CopyFunc              func(ctx context.Context, src, dst string, bufferSize int) (int64, error)
+	ExistsFunc            func(ctx context.Context, path string) (bool, error)
+	GetMetadataFunc       func(ctx context.Context, path string) (backup.FileMetadata, error)
+	CreateDirectoryFunc   func(ctx context.Context, path string) error
 	IsDirectoryFunc       func(path string) (bool, error)
+	RenameFunc            func(oldPath, newPath string) error
+	SameLogicalPathFunc   func(a, b string) bool
 }
 
 func NewMockStorageAdapter() *MockStorageAdapter {
 	return &MockStorageAdapter{
-		CalculateChecksumFunc: func(filePath string) (string, error) {
+		CalculateChecksumFunc: func(ctx context.Context, filePath string) (string, error) {
 			return "mock-checksum", nil
 		},
-		CopyFunc: func(src, dst string, bufferSize int) (int64, error) {
+		CopyFunc: func(ctx context.Context, src, dst string, bufferSize int) (int64, error) {
 			return 1024, nil
 		},
-		ExistsFunc: func(path string) (bool, error) {
+		ExistsFunc: func(ctx context.Context, path string) (bool, error) {
 			return true, nil
 		},
-		GetMetadataFunc: func(path string) (backup.FileMetadata, error) {
+		GetMetadataFunc: func(ctx context.Context, path string) (backup.FileMetadata, error) {
 			return backup.FileMetadata{
 				Path:     path,
 				Size:     1024,
@@ -34,36 +38,50 @@ func NewMockStorageAdapter() *MockStorageAdapter {
 				Checksum: "mock-checksum",
 			}, nil
 		},
-		CreateDirectoryFunc: func(path string) error {
+		CreateDirectoryFunc: func(ctx context.Context, path string) error {
 			return nil
 		},
 		IsDirectoryFunc: func(path string) (bool, error) {
 			return false, nil
 		},
+		RenameFunc: func(oldPath, newPath string) error {
+			return nil
+		},
+		SameLogicalPathFunc: func(a, b string) bool {
+			return norm.NFC.String(a) == norm.NFC.String(b)
+		},
 	}
 }
 
 // Implement all interface methods using the function fields
-func (m *MockStorageAdapter) CalculateChecksum(filePath string) (string, error) {
-	return m.CalculateChecksumFunc(filePath)
+func (m *MockStorageAdapter) CalculateChecksum(ctx context.Context, filePath string) (string, error) {
+	return m.CalculateChecksumFunc(ctx, filePath)
 }
 
-func (m *MockStorageAdapter) Copy(src, dst string, bufferSize int) (int64, error) {
-	return m.CopyFunc(src, dst, bufferSize)
+func (m *MockStorageAdapter) Copy(ctx context.Context, src, dst string, bufferSize int) (int64, error) {
+	return m.CopyFunc(ctx, src, dst, bufferSize)
 }
 
-func (m *MockStorageAdapter) Exists(path string) (bool, error) {
-	return m.ExistsFunc(path)
+func (m *MockStorageAdapter) Exists(ctx context.Context, path string) (bool, error) {
+	return m.ExistsFunc(ctx, path)
 }
 
-func (m *MockStorageAdapter) GetMetadata(path string) (backup.FileMetadata, error) {
-	return m.GetMetadataFunc(path)
+func (m *MockStorageAdapter) GetMetadata(ctx context.Context, path string) (backup.FileMetadata, error) {
+	return m.GetMetadataFunc(ctx, path)
 }
 
-func (m *MockStorageAdapter) CreateDirectory(path string) error {
-	return m.CreateDirectoryFunc(path)
+func (m *MockStorageAdapter) CreateDirectory(ctx context.Context, path string) error {
+	return m.CreateDirectoryFunc(ctx, path)
 }
 
 func (m *MockStorageAdapter) IsDirectory(path string) (bool, error) {
 	return m.IsDirectoryFunc(path)
 }
+
+func (m *MockStorageAdapter) Rename(oldPath, newPath string) error {
+	return m.RenameFunc(oldPath, newPath)
+}
+
+func (m *MockStorageAdapter) SameLogicalPath(a, b string) bool {
+	return m.SameLogicalPathFunc(a, b)
+}
@@ -0,0 +1,80 @@
+// Package registry lets each storage backend (filesystem, s3, sftp, ...)
+// register itself under a URI scheme, the same driver-registration pattern
+// database/sql uses for SQL drivers, so app.Factory can pick a
+// backup.StoragePort implementation from config.TargetDirectory's scheme
+// without importing every backend package directly.
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+)
+
+// Options carries the subset of config a Driver needs to open a
+// backup.StoragePort, gathered here rather than passed as
+// *backup.BackupConfig so drivers stay decoupled from the config package's
+// field layout.
+type Options struct {
+	ChecksumAlgorithm string
+	BufferSize        int
+}
+
+// Driver opens a backup.StoragePort rooted at target - the host+path
+// portion of config.TargetDirectory once its scheme has selected this
+// Driver, e.g. "bucket/prefix" for "s3://bucket/prefix".
+type Driver interface {
+	Open(target string, opts Options) (backup.StoragePort, error)
+}
+
+var (
+	mu      sync.RWMutex
+	drivers = make(map[string]Driver)
+)
+
+// Register registers driver under scheme. It panics on a nil driver or a
+// duplicate scheme, the same database/sql convention - both are
+// programming errors caught at init time, not runtime conditions a caller
+// could sensibly recover from.
+func Register(scheme string, driver Driver) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if driver == nil {
+		panic("storage/registry: Register driver is nil")
+	}
+	if _, dup := drivers[scheme]; dup {
+		panic("storage/registry: Register called twice for scheme " + scheme)
+	}
+	drivers[scheme] = driver
+}
+
+// Open parses target as a URI and dispatches to the Driver registered for
+// its scheme. A target with no "scheme://" prefix - a plain filesystem
+// path, the common case today - is treated as scheme "file".
+func Open(target string, opts Options) (backup.StoragePort, error) {
+	scheme, rest := splitScheme(target)
+
+	mu.RLock()
+	driver, ok := drivers[scheme]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("storage/registry: no driver registered for scheme %q (target %q)", scheme, target)
+	}
+
+	return driver.Open(rest, opts)
+}
+
+// splitScheme separates target's URI scheme from the remainder (host plus
+// path, e.g. "bucket/prefix" for "s3://bucket/prefix"). A target with no
+// scheme, or one url.Parse can't make sense of as a URI, is returned
+// whole under scheme "file".
+func splitScheme(target string) (scheme, rest string) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" {
+		return "file", target
+	}
+	return u.Scheme, u.Host + u.Path
+}
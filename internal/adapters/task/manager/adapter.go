@@ -2,6 +2,8 @@
 package manager
 
 import (
+	"context"
+
 	"github.com/jack-sneddon/backup-butler/internal/core/task"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
 )
@@ -23,16 +25,31 @@ func (t *TaskManager) SetConfig(config *backup.BackupConfig) {
 	t.manager.SetConfig(config)
 }
 
-func (t *TaskManager) CreateTasks(config *backup.BackupConfig) ([]backup.BackupTask, int, error) {
-	return t.manager.CreateTasks(config)
+// SetCheckpoint attaches a checkpoint journal to the underlying Manager.
+// Not part of TaskManagerPort - app.Factory calls this directly when it
+// opens one, the same way it reaches FilesystemAdapter.SetCache through
+// an interface check rather than a StoragePort method.
+func (t *TaskManager) SetCheckpoint(c *task.Checkpoint) {
+	t.manager.SetCheckpoint(c)
+}
+
+// CompactCheckpoint delegates to the underlying Manager. core.backup.Service
+// looks this up through an interface check once a run's version has saved
+// cleanly, the same way it reaches CacheStats/FlushCache/BytesReused.
+func (t *TaskManager) CompactCheckpoint() error {
+	return t.manager.CompactCheckpoint()
+}
+
+func (t *TaskManager) CreateTasks(ctx context.Context, config *backup.BackupConfig) ([]backup.BackupTask, int, error) {
+	return t.manager.CreateTasks(ctx, config)
 }
 
-func (t *TaskManager) ShouldSkipFile(task backup.BackupTask) (bool, error) {
-	return t.manager.ShouldSkipFile(task)
+func (t *TaskManager) ShouldSkipFile(ctx context.Context, task backup.BackupTask) (bool, error) {
+	return t.manager.ShouldSkipFile(ctx, task)
 }
 
-func (t *TaskManager) ExecuteTask(task backup.BackupTask) error {
-	return t.manager.ExecuteTask(task)
+func (t *TaskManager) ExecuteTask(ctx context.Context, task backup.BackupTask) error {
+	return t.manager.ExecuteTask(ctx, task)
 }
 
 func (t *TaskManager) ValidateTask(task backup.BackupTask) error {
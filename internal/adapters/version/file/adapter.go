@@ -27,6 +27,22 @@ func NewFileVersionManager(baseDir string) (*FileVersionManager, error) {
 		return nil, fmt.Errorf("failed to create versions directory: %w", err)
 	}
 
+	// Refuse to operate against a repository written by an older format
+	// than this build expects - UpgradeRepository must migrate it first.
+	// A brand-new, still-empty repository is initialized straight at
+	// CurrentRepositoryVersion instead, since there's nothing to migrate.
+	formatVersion, fresh, err := readRepositoryVersion(versionsDir)
+	if err != nil {
+		return nil, err
+	}
+	if fresh {
+		if err := initRepositoryMarker(versionsDir); err != nil {
+			return nil, fmt.Errorf("failed to initialize repository marker: %w", err)
+		}
+	} else if formatVersion < CurrentRepositoryVersion {
+		return nil, &ErrUpgradeRequired{BaseDir: baseDir, FormatVersion: formatVersion}
+	}
+
 	// Load existing versions
 	if err := vm.loadVersions(); err != nil {
 		return nil, err
@@ -76,6 +92,25 @@ func (vm *FileVersionManager) CompleteVersion(stats backup.BackupStats) error {
 	return nil
 }
 
+func (vm *FileVersionManager) CancelVersion(stats backup.BackupStats) error {
+	if vm.currentVer == nil {
+		return fmt.Errorf("no backup version in progress")
+	}
+
+	vm.currentVer.Status = "Cancelled"
+	vm.currentVer.Duration = time.Since(vm.currentVer.Timestamp)
+	vm.currentVer.Stats = stats
+
+	if err := vm.saveVersion(vm.currentVer); err != nil {
+		return err
+	}
+
+	vm.versions = append(vm.versions, *vm.currentVer)
+	vm.currentVer = nil
+
+	return nil
+}
+
 func (vm *FileVersionManager) saveVersion(ver *backup.BackupVersion) error {
 	filename := filepath.Join(vm.baseDir, ".versions", ver.ID+".json")
 
@@ -84,9 +119,16 @@ func (vm *FileVersionManager) saveVersion(ver *backup.BackupVersion) error {
 		return fmt.Errorf("failed to marshal version data: %w", err)
 	}
 
-	if err := os.WriteFile(filename, data, 0644); err != nil {
+	// Write to a temp file and rename into place so a second SIGINT landing
+	// mid-write (or a crash) can never leave a truncated version file behind;
+	// readers only ever see the old file or the fully-written new one.
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
 		return fmt.Errorf("failed to save version file: %w", err)
 	}
+	if err := os.Rename(tmp, filename); err != nil {
+		return fmt.Errorf("failed to finalize version file: %w", err)
+	}
 
 	return nil
 }
@@ -102,6 +144,9 @@ func (vm *FileVersionManager) loadVersions() error {
 	}
 
 	for _, entry := range entries {
+		if entry.Name() == repositoryMarkerFile || entry.Name() == checksumIndexFile {
+			continue
+		}
 		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
 			filename := filepath.Join(versionsDir, entry.Name())
 			data, err := os.ReadFile(filename)
@@ -0,0 +1,246 @@
+// internal/adapters/version/file/repoversion.go
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CurrentRepositoryVersion is the on-disk format FileVersionManager writes
+// and expects to read. A repository with no repository.json marker at all
+// predates this versioning scheme (format 1: per-run version files only,
+// no separate checksum index); bumping this constant for a future format
+// change means writing a migration in UpgradeRepository and updating the
+// comparison in readRepositoryVersion.
+const CurrentRepositoryVersion = 2
+
+const repositoryMarkerFile = "repository.json"
+const checksumIndexFile = "checksum_index.json"
+
+type repositoryMarker struct {
+	FormatVersion int `json:"format_version"`
+}
+
+// ErrUpgradeRequired is returned by NewFileVersionManager when the
+// repository at BaseDir was written by an older FormatVersion than
+// CurrentRepositoryVersion. The caller must run UpgradeRepository before
+// any normal read/write operation against it.
+type ErrUpgradeRequired struct {
+	BaseDir       string
+	FormatVersion int
+}
+
+func (e *ErrUpgradeRequired) Error() string {
+	return fmt.Sprintf(
+		"repository at %s is format v%d, but this build expects v%d - run `backup-butler -upgrade` to migrate it before continuing",
+		e.BaseDir, e.FormatVersion, CurrentRepositoryVersion,
+	)
+}
+
+// readRepositoryVersion reports the on-disk format version of the
+// repository rooted at versionsDir, and whether this is a brand-new,
+// still-empty repository rather than an old-format one - a fresh
+// repository has no marker and no version files to migrate, so it's
+// initialized straight at CurrentRepositoryVersion instead of being
+// treated as needing an upgrade.
+func readRepositoryVersion(versionsDir string) (version int, fresh bool, err error) {
+	data, err := os.ReadFile(filepath.Join(versionsDir, repositoryMarkerFile))
+	if err == nil {
+		var marker repositoryMarker
+		if err := json.Unmarshal(data, &marker); err != nil {
+			return 0, false, fmt.Errorf("failed to parse repository marker: %w", err)
+		}
+		return marker.FormatVersion, false, nil
+	}
+	if !os.IsNotExist(err) {
+		return 0, false, fmt.Errorf("failed to read repository marker: %w", err)
+	}
+
+	// No marker. Distinguish "never written" (fresh) from "predates the
+	// marker entirely" (format 1) by whether any version files exist yet.
+	entries, readErr := os.ReadDir(versionsDir)
+	if readErr != nil {
+		return 0, false, fmt.Errorf("failed to read versions directory: %w", readErr)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		if entry.Name() == repositoryMarkerFile || entry.Name() == checksumIndexFile {
+			continue
+		}
+		return 1, false, nil
+	}
+
+	return CurrentRepositoryVersion, true, nil
+}
+
+// initRepositoryMarker writes the marker for a brand-new repository. There
+// is nothing to back up - a fresh repository has no prior marker.
+func initRepositoryMarker(versionsDir string) error {
+	return writeMarkerAtomically(versionsDir, CurrentRepositoryVersion, time.Now())
+}
+
+// UpgradeRepository migrates the repository rooted at baseDir from
+// whatever format it's currently in up to CurrentRepositoryVersion. Today
+// that means building a checksum_index.json - path -> most-recent-known
+// checksum, derived from every existing per-run version file - so later
+// code can look a file's last-known checksum up without scanning every
+// version file in the repository, then recording the new format version.
+// Both writes land via a temp-file-then-rename so a crash mid-upgrade
+// never leaves a torn file in place.
+func UpgradeRepository(baseDir string) error {
+	versionsDir := filepath.Join(baseDir, ".versions")
+
+	currentVersion, fresh, err := readRepositoryVersion(versionsDir)
+	if err != nil {
+		return err
+	}
+	if fresh {
+		return initRepositoryMarker(versionsDir)
+	}
+	if currentVersion >= CurrentRepositoryVersion {
+		return nil
+	}
+
+	index, err := buildChecksumIndex(versionsDir)
+	if err != nil {
+		return fmt.Errorf("failed to build checksum index: %w", err)
+	}
+
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checksum index: %w", err)
+	}
+	if err := writeFileAtomically(filepath.Join(versionsDir, checksumIndexFile), indexData); err != nil {
+		return fmt.Errorf("failed to write checksum index: %w", err)
+	}
+
+	return writeMarkerAtomically(versionsDir, CurrentRepositoryVersion, time.Now())
+}
+
+// buildChecksumIndex reads every per-run version file under versionsDir,
+// sorted by ID (version IDs are timestamp-formatted, so this is also
+// chronological order) so that a path backed up in more than one version
+// ends up keyed to its most recent checksum.
+func buildChecksumIndex(versionsDir string) (map[string]string, error) {
+	entries, err := os.ReadDir(versionsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		if entry.Name() == repositoryMarkerFile || entry.Name() == checksumIndexFile {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	index := make(map[string]string)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(versionsDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read version file %s: %w", name, err)
+		}
+
+		var version struct {
+			Files map[string]struct {
+				Checksum string `json:"Checksum"`
+			} `json:"Files"`
+		}
+		if err := json.Unmarshal(data, &version); err != nil {
+			return nil, fmt.Errorf("failed to parse version file %s: %w", name, err)
+		}
+
+		for path, meta := range version.Files {
+			if meta.Checksum != "" {
+				index[path] = meta.Checksum
+			}
+		}
+	}
+
+	return index, nil
+}
+
+// UpgradeFailedError is returned when swapping in an upgraded repository
+// marker fails after the previous marker was already moved aside to back
+// it up, and restoring that backup also failed. The repository is left
+// without a marker at versionsDir/repository.json; BackupPath is where the
+// pre-upgrade marker can still be recovered from manually.
+type UpgradeFailedError struct {
+	BackupPath string
+	Cause      error
+	RestoreErr error
+}
+
+func (e *UpgradeFailedError) Error() string {
+	return fmt.Sprintf(
+		"failed to finalize repository upgrade (%v), and restoring the pre-upgrade marker from %s also failed (%v) - recover manually from %s",
+		e.Cause, e.BackupPath, e.RestoreErr, e.BackupPath,
+	)
+}
+
+func (e *UpgradeFailedError) Unwrap() error {
+	return e.Cause
+}
+
+// writeFileAtomically writes data to path via a temp file in the same
+// directory followed by a rename, so a reader never observes a partially
+// written file.
+func writeFileAtomically(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writeMarkerAtomically writes the repository marker recording version,
+// backing up any existing marker to repository.json.bak.<unix-timestamp>
+// first. If the final rename fails after the old marker has already been
+// moved aside, it tries to restore that backup so the repository isn't
+// left without a marker at all; if the restore itself fails too, it
+// returns an UpgradeFailedError naming the backup path for manual
+// recovery.
+func writeMarkerAtomically(versionsDir string, version int, now time.Time) error {
+	data, err := json.MarshalIndent(repositoryMarker{FormatVersion: version}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository marker: %w", err)
+	}
+
+	markerPath := filepath.Join(versionsDir, repositoryMarkerFile)
+	tmp := markerPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write new repository marker: %w", err)
+	}
+
+	if _, statErr := os.Stat(markerPath); os.IsNotExist(statErr) {
+		if err := os.Rename(tmp, markerPath); err != nil {
+			return fmt.Errorf("failed to finalize repository marker: %w", err)
+		}
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%s.bak.%d", markerPath, now.Unix())
+	if err := os.Rename(markerPath, backupPath); err != nil {
+		return fmt.Errorf("failed to back up existing repository marker to %s: %w", backupPath, err)
+	}
+
+	if err := os.Rename(tmp, markerPath); err != nil {
+		if restoreErr := os.Rename(backupPath, markerPath); restoreErr != nil {
+			return &UpgradeFailedError{BackupPath: backupPath, Cause: err, RestoreErr: restoreErr}
+		}
+		return fmt.Errorf("failed to finalize repository marker (restored previous marker from %s): %w", backupPath, err)
+	}
+
+	return nil
+}
@@ -54,6 +54,21 @@ func (m *MockVersionManager) CompleteVersion(stats backup.BackupStats) error {
 	return nil
 }
 
+func (m *MockVersionManager) CancelVersion(stats backup.BackupStats) error {
+	if m.currentVer == nil {
+		return fmt.Errorf("no backup version in progress")
+	}
+
+	m.currentVer.Status = "Cancelled"
+	m.currentVer.Duration = time.Since(m.currentVer.Timestamp)
+	m.currentVer.Stats = stats
+
+	m.versions = append(m.versions, *m.currentVer)
+	m.currentVer = nil
+
+	return nil
+}
+
 func (m *MockVersionManager) GetVersions() []backup.BackupVersion {
 	return m.versions
 }
@@ -27,3 +27,17 @@ func NewWorkerPool(
 func (a *WorkerPoolAdapter) Execute(ctx context.Context, tasks []backup.BackupTask) <-chan backup.TaskResult {
 	return a.pool.Execute(ctx, tasks)
 }
+
+// ExecuteStream consumes tasks from a channel as they arrive instead of
+// requiring the full slice up front, so a streaming scan can feed the pool
+// directly and copying can start before discovery finishes.
+func (a *WorkerPoolAdapter) ExecuteStream(ctx context.Context, tasks <-chan backup.BackupTask) <-chan backup.TaskResult {
+	return a.pool.ExecuteStream(ctx, tasks)
+}
+
+// SetReporter attaches a worker.Reporter to the underlying pool so callers
+// wired only against backup.WorkerPoolPort can still opt into per-task
+// progress events via a type assertion.
+func (a *WorkerPoolAdapter) SetReporter(r worker.Reporter) {
+	a.pool.SetReporter(r)
+}
@@ -6,11 +6,18 @@ import (
 	configadapter "github.com/jack-sneddon/backup-butler/internal/adapters/config/file"
 	"github.com/jack-sneddon/backup-butler/internal/adapters/metrics/collector"
 	backupservice "github.com/jack-sneddon/backup-butler/internal/adapters/service/backup"
-	storageadapter "github.com/jack-sneddon/backup-butler/internal/adapters/storage/filesystem"
+	// Blank-imported for their self-registration into storage/registry -
+	// see internal/adapters/storage/registry. Add a new backend's import
+	// here to make it selectable via config.TargetDirectory's scheme.
+	_ "github.com/jack-sneddon/backup-butler/internal/adapters/storage/filesystem"
+	"github.com/jack-sneddon/backup-butler/internal/adapters/storage/registry"
 	"github.com/jack-sneddon/backup-butler/internal/adapters/task/manager"
 	versionadapter "github.com/jack-sneddon/backup-butler/internal/adapters/version/file"
 	pooladapter "github.com/jack-sneddon/backup-butler/internal/adapters/worker/pool"
+	"github.com/jack-sneddon/backup-butler/internal/core/storage/cache"
+	"github.com/jack-sneddon/backup-butler/internal/core/task"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/lazy"
 )
 
 // Factory handles the creation and wiring of all components
@@ -24,52 +31,120 @@ func NewFactory(configPath string) *Factory {
 	}
 }
 
-func (f *Factory) CreateBackupService() (backup.BackupService, error) {
-	// 1. Load configuration
+// CreateBackupService wires up a BackupService whose components - storage,
+// version manager, metrics, task manager, worker pool - are each wrapped
+// in a lazy.Lazy and only actually constructed the first time something
+// calls for them. This is what lets --dry-run or a health check touch
+// only the subsystems they need: a DryRun never opens the worker pool.
+// HealthCheck's worker-pool probe does construct the pool, and with it the
+// task manager, storage (checksum cache and any remote backend included)
+// and checkpoint journal.
+//
+// restart discards any checkpoint journal left by a previous, interrupted
+// run against the same target rather than resuming from it - resuming is
+// the default, the same way rclone/restic treat an interrupted run as safe
+// to pick back up from rather than something the caller must opt into
+// continuing.
+func (f *Factory) CreateBackupService(jsonMode bool, restart bool) (backup.BackupService, error) {
 	configLoader := configadapter.NewFileConfigLoader()
 	config, err := configLoader.Load(f.configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
+	config.Options.JSON = jsonMode
 
-	// 2. Create storage adapter
-	//storage := storageadapter.NewFilesystemAdapter(config.ChecksumAlgorithm)
-	// Create storage adapter
-	storage := storageadapter.NewFilesystemAdapter(
-		config.ChecksumAlgorithm,
-		config.BufferSize,
-	)
+	// Storage adapter, picked by config.TargetDirectory's URI scheme
+	// (e.g. "s3://bucket/prefix" selects the s3 driver; a plain path with
+	// no scheme falls back to "file").
+	storageLazy := lazy.New(func() (backup.StoragePort, error) {
+		storage, err := registry.Open(config.TargetDirectory, registry.Options{
+			ChecksumAlgorithm: config.ChecksumAlgorithm,
+			BufferSize:        config.BufferSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open storage backend: %w", err)
+		}
 
-	// 3. Create version manager
-	versioner, err := versionadapter.NewFileVersionManager(config.TargetDirectory)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create version manager: %w", err)
-	}
+		if config.ChecksumCache {
+			if cacheSetter, ok := storage.(interface{ SetCache(*cache.Store) }); ok {
+				checksumCache, err := cache.Open(config.TargetDirectory)
+				if err != nil {
+					return nil, fmt.Errorf("failed to open checksum cache: %w", err)
+				}
+				cacheSetter.SetCache(checksumCache)
+			}
+		}
+
+		return storage, nil
+	})
+
+	versionerLazy := lazy.New(func() (backup.VersionManagerPort, error) {
+		versioner, err := versionadapter.NewFileVersionManager(config.TargetDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create version manager: %w", err)
+		}
+		return versioner, nil
+	})
+
+	metricsLazy := lazy.New(func() (backup.MetricsPort, error) {
+		return collector.NewMetricsCollector(0, config.Options.Quiet, jsonMode), nil
+	})
+
+	taskMgrLazy := lazy.New(func() (backup.TaskManagerPort, error) {
+		storage, err := storageLazy.Get()
+		if err != nil {
+			return nil, err
+		}
+		metrics, err := metricsLazy.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		taskMgr := manager.NewTaskManager(storage, metrics)
+		taskMgr.SetConfig(config)
 
-	// 4. Create metrics collector
-	metrics := collector.NewMetricsCollector(0, config.Options.Quiet)
+		checkpoint, err := task.OpenCheckpoint(config.TargetDirectory, restart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open checkpoint journal: %w", err)
+		}
+		taskMgr.SetCheckpoint(checkpoint)
 
-	// 5. Create task manager
-	taskMgr := manager.NewTaskManager(storage, metrics)
-	taskMgr.SetConfig(config)
+		return taskMgr, nil
+	})
 
-	// 6. Create worker pool
-	workerPool := pooladapter.NewWorkerPool(
-		config.Concurrency,
-		taskMgr,
-		config.RetryAttempts,
-		config.RetryDelay,
-	)
+	workerPoolLazy := lazy.New(func() (backup.WorkerPoolPort, error) {
+		taskMgr, err := taskMgrLazy.Get()
+		if err != nil {
+			return nil, err
+		}
+		return pooladapter.NewWorkerPool(
+			config.Concurrency,
+			taskMgr,
+			config.RetryAttempts,
+			config.RetryDelay,
+		), nil
+	})
 
-	// 7. Create backup service
-	service := backupservice.NewBackupService(
+	return backupservice.NewBackupService(
 		config,
-		storage,
-		metrics,
-		versioner,
-		taskMgr,
-		workerPool,
-	)
-
-	return service, nil
+		storageLazy,
+		metricsLazy,
+		versionerLazy,
+		taskMgrLazy,
+		workerPoolLazy,
+	), nil
+}
+
+// UpgradeRepository migrates the backup target's repository format (see
+// versionadapter.UpgradeRepository) up to versionadapter.CurrentRepositoryVersion.
+// Run this before CreateBackupService against a target that returned a
+// versionadapter.ErrUpgradeRequired error.
+func (f *Factory) UpgradeRepository() error {
+	configLoader := configadapter.NewFileConfigLoader()
+	config, err := configLoader.Load(f.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return versionadapter.UpgradeRepository(config.TargetDirectory)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/jack-sneddon/backup-butler/internal/config"
 	"github.com/jack-sneddon/backup-butler/internal/storage"
 	"github.com/jack-sneddon/backup-butler/internal/version"
+	"github.com/jack-sneddon/backup-butler/internal/version/chunkstore"
 )
 
 type Service struct {
@@ -21,6 +22,10 @@ type Service struct {
 	copier         *storage.Copier
 	versionMgr     *version.Manager
 	stats          *atomic.Value // *BackupStats
+	taskSource     TaskSource
+
+	session      *Session
+	resumeStates map[string]TaskState
 }
 
 type BackupStats struct {
@@ -35,8 +40,9 @@ type BackupStats struct {
 
 func NewService(cfg *config.Config) (*Service, error) {
 	storageManager := storage.NewManager(cfg.TargetDirectory, cfg.BufferSize, cfg)
-	copier := storage.NewCopier(storageManager, cfg.BufferSize)
-	versionMgr, err := version.NewManager(cfg.TargetDirectory)
+	copier := storage.NewCopier(storageManager, cfg.BufferSize, cfg.Concurrency)
+	copier.SetChunkCacheSize(cfg.ChunkCacheSize)
+	versionMgr, err := version.NewManager(cfg.TargetDirectory, cfg.BufferSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize version manager: %w", err)
 	}
@@ -50,12 +56,62 @@ func NewService(cfg *config.Config) (*Service, error) {
 		copier:         copier,
 		versionMgr:     versionMgr,
 		stats:          stats,
+		taskSource:     taskSourceFromConfig(cfg),
 	}, nil
 }
 
+// taskSourceFromConfig picks the TaskSource implied by the config: a curated
+// files-from list, a single file streamed over stdin, or the default
+// folders_to_backup walk.
+func taskSourceFromConfig(cfg *config.Config) TaskSource {
+	switch {
+	case cfg.StdinFilename != "":
+		return stdinTaskSource{filename: cfg.StdinFilename}
+	case cfg.FilesFrom != "":
+		return filesFromTaskSource{listPath: cfg.FilesFrom}
+	default:
+		return walkTaskSource{}
+	}
+}
+
+// Backup starts a fresh backup version and its session journal.
 func (s *Service) Backup(ctx context.Context) error {
 	ver := s.versionMgr.StartNewVersion(s.config)
-	fmt.Printf("Starting backup version: %s\n", ver.ID)
+	return s.runSession(ctx, ver.ID, map[string]TaskState{})
+}
+
+// Resume reattaches to an interrupted backup version, replaying its
+// session journal so already-verified tasks are skipped instead of
+// rescanned and rehashed.
+func (s *Service) Resume(ctx context.Context, versionID string) error {
+	states, err := LoadSessionStates(s.config.TargetDirectory, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to load session %s: %w", versionID, err)
+	}
+
+	s.versionMgr.StartVersionWithID(s.config, versionID)
+	fmt.Printf("Resuming backup version: %s\n", versionID)
+	return s.runSession(ctx, versionID, states)
+}
+
+// AbandonSession discards an interrupted version's session journal without
+// finalizing it, so a later run starts that version over from scratch.
+func (s *Service) AbandonSession(versionID string) error {
+	return AbandonSession(s.config.TargetDirectory, versionID)
+}
+
+func (s *Service) runSession(ctx context.Context, versionID string, resumeStates map[string]TaskState) error {
+	if resumeStates == nil {
+		fmt.Printf("Starting backup version: %s\n", versionID)
+	}
+
+	session, err := NewSession(s.config.TargetDirectory, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to open session journal: %w", err)
+	}
+	defer session.Close()
+	s.session = session
+	s.resumeStates = resumeStates
 
 	tasks, err := s.scanSourceDirectory()
 	if err != nil {
@@ -74,60 +130,26 @@ func (s *Service) Backup(ctx context.Context) error {
 	fmt.Printf("Files skipped: %d (%.2f MB)\n", stats.FilesSkipped, float64(stats.BytesSkipped)/(1024*1024))
 	fmt.Printf("Files failed: %d\n", stats.FilesFailed)
 
-	return s.versionMgr.CompleteVersion()
+	if err := s.versionMgr.CompleteVersion(); err != nil {
+		return err
+	}
+	return completeSession(s.config.TargetDirectory, versionID)
 }
 
 type BackupTask struct {
 	SourcePath   string
 	DestPath     string
 	RelativePath string
+
+	// PrecomputedChecksum/PrecomputedSize are set by sources that already
+	// hashed their content while streaming it (stdinTaskSource), so
+	// processTask doesn't need a second, rewinding read of the source.
+	PrecomputedChecksum string
+	PrecomputedSize     int64
 }
 
 func (s *Service) scanSourceDirectory() ([]BackupTask, error) {
-	var tasks []BackupTask
-
-	for _, folder := range s.config.FoldersToBackup {
-		srcPath := filepath.Join(s.config.SourceDirectory, folder)
-
-		err := filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// Skip directories
-			if info.IsDir() {
-				return nil
-			}
-
-			// Check exclude patterns
-			for _, pattern := range s.config.ExcludePatterns {
-				if matched, _ := filepath.Match(pattern, info.Name()); matched {
-					return nil
-				}
-			}
-
-			relPath, err := filepath.Rel(s.config.SourceDirectory, path)
-			if err != nil {
-				return fmt.Errorf("failed to get relative path: %w", err)
-			}
-
-			destPath := filepath.Join(s.config.TargetDirectory, relPath)
-
-			tasks = append(tasks, BackupTask{
-				SourcePath:   path,
-				DestPath:     destPath,
-				RelativePath: relPath,
-			})
-
-			return nil
-		})
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan folder %s: %w", folder, err)
-		}
-	}
-
-	return tasks, nil
+	return s.taskSource.Tasks(s.config)
 }
 
 func (s *Service) processBackupTasks(ctx context.Context, tasks []BackupTask) error {
@@ -174,6 +196,14 @@ func (s *Service) processBackupTasks(ctx context.Context, tasks []BackupTask) er
 
 // internal/backup/service.go
 func (s *Service) processTask(ctx context.Context, task BackupTask) error {
+	if s.resumeStates[task.RelativePath] == TaskVerified {
+		s.incrementStats(func(stats *BackupStats) {
+			stats.FilesProcessed++
+			stats.FilesSkipped++
+		})
+		return nil
+	}
+
 	meta, err := s.storageManager.GetMetadata(task.SourcePath)
 	if err != nil {
 		s.incrementStats(func(stats *BackupStats) {
@@ -187,10 +217,14 @@ func (s *Service) processTask(ctx context.Context, task BackupTask) error {
 			0, // size is 0 for failed files
 			time.Time{},
 			"", // no checksum for failed files
+			nil,
 		)
+		s.session.Record(task.RelativePath, TaskFailed)
 		return fmt.Errorf("failed to get source metadata: %w", err)
 	}
 
+	s.session.Record(task.RelativePath, TaskHashed)
+
 	compareResult, err := s.storageManager.Compare(task.SourcePath, task.DestPath, s.versionMgr)
 	if err != nil {
 		compareResult = storage.CompareResult{NeedsCopy: true}
@@ -209,11 +243,24 @@ func (s *Service) processTask(ctx context.Context, task BackupTask) error {
 				meta.Size,
 				meta.ModTime,
 				"",
+				nil,
 			)
+			s.session.Record(task.RelativePath, TaskFailed)
 			return fmt.Errorf("failed to copy file: %w", err)
 		}
-
-		if err := s.copier.VerifyCopy(task.SourcePath, task.DestPath); err != nil {
+		s.session.Record(task.RelativePath, TaskCopied)
+
+		var verifyErr error
+		if task.PrecomputedChecksum != "" {
+			// The source was already hashed while streaming (stdin mode);
+			// re-reading it here would defeat the point, so only the
+			// destination needs to be checked against that checksum.
+			verifyErr = storage.NewChecksumCalculator().VerifyChecksum(task.DestPath, task.PrecomputedChecksum)
+		} else {
+			verifyErr = s.copier.VerifyCopy(task.SourcePath, task.DestPath)
+		}
+		if verifyErr != nil {
+			err := verifyErr
 			s.incrementStats(func(stats *BackupStats) {
 				stats.FilesProcessed++
 				stats.FilesFailed++
@@ -224,9 +271,11 @@ func (s *Service) processTask(ctx context.Context, task BackupTask) error {
 				meta.Size,
 				meta.ModTime,
 				"",
+				nil,
 			)
 			return fmt.Errorf("copy verification failed: %w", err)
 		}
+		s.session.Record(task.RelativePath, TaskVerified)
 
 		s.incrementStats(func(stats *BackupStats) {
 			stats.FilesProcessed++
@@ -235,14 +284,23 @@ func (s *Service) processTask(ctx context.Context, task BackupTask) error {
 			stats.BytesProcessed += result.BytesCopied
 		})
 
+		var chunks []chunkstore.ChunkRef
+		if data, readErr := os.ReadFile(task.DestPath); readErr == nil {
+			if chunks, err = s.versionMgr.ChunkFile(data); err != nil {
+				return fmt.Errorf("failed to chunk copied file: %w", err)
+			}
+		}
+
 		s.versionMgr.RecordFile(
 			task.RelativePath,
 			"copied",
 			meta.Size,
 			meta.ModTime,
 			meta.Checksum,
+			chunks,
 		)
 	} else {
+		s.session.Record(task.RelativePath, TaskVerified)
 		s.incrementStats(func(stats *BackupStats) {
 			stats.FilesProcessed++
 			stats.FilesSkipped++
@@ -250,12 +308,20 @@ func (s *Service) processTask(ctx context.Context, task BackupTask) error {
 			stats.BytesProcessed += meta.Size
 		})
 
+		// Unchanged file: cite the chunks already on file rather than
+		// re-chunking a copy that didn't move.
+		chunks := s.versionMgr.ChunksForPath(task.RelativePath)
+		if err := s.versionMgr.ReuseChunks(chunks); err != nil {
+			return fmt.Errorf("failed to reuse chunk manifest: %w", err)
+		}
+
 		s.versionMgr.RecordFile(
 			task.RelativePath,
 			"skipped",
 			meta.Size,
 			meta.ModTime,
 			meta.Checksum,
+			chunks,
 		)
 	}
 
@@ -277,6 +343,46 @@ func (s *Service) GetVersionHistory() ([]version.VersionSummary, error) {
 	return s.versionMgr.GetVersions()
 }
 
+// RestoreFile reassembles relPath as it was recorded in versionID from the
+// content-addressed chunk store and writes it to outputPath, for pulling a
+// single file back out of history without touching the live mirror at
+// TargetDirectory.
+func (s *Service) RestoreFile(versionID, relPath, outputPath string) error {
+	data, err := s.versionMgr.RestoreFile(versionID, relPath)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s from version %s: %w", relPath, versionID, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write restored file: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) GetIntegrityIssues() ([]*storage.IntegrityCheck, error) {
 	return s.storageManager.GetIntegrityIssues()
 }
+
+// ChecksumWildcard hashes every source file matching pattern, for verifying
+// a targeted subset (e.g. "my RAW photos this week") without walking the
+// whole tree.
+func (s *Service) ChecksumWildcard(ctx context.Context, pattern string) ([]storage.WildcardChecksum, error) {
+	return s.storageManager.ChecksumWildcard(ctx, s.config.SourceDirectory, pattern)
+}
+
+// Prune applies a version retention policy (keep-last / keep-within /
+// keep-storage) to the version history, deleting any version that falls
+// outside every retained window. In dry-run mode it reports what would be
+// removed without touching the journal.
+func (s *Service) Prune(opts version.PruneOptions) (*version.PruneReport, error) {
+	report, err := s.versionMgr.Prune(opts)
+	if err != nil {
+		return nil, fmt.Errorf("prune failed: %w", err)
+	}
+	return report, nil
+}
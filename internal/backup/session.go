@@ -0,0 +1,184 @@
+// internal/backup/session.go
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TaskState tracks a single task's progress through the pipeline so a
+// resumed run knows exactly how far it got before being interrupted.
+type TaskState string
+
+const (
+	TaskPending  TaskState = "pending"
+	TaskHashed   TaskState = "hashed"
+	TaskCopied   TaskState = "copied"
+	TaskVerified TaskState = "verified"
+	TaskFailed   TaskState = "failed"
+)
+
+// journalEntry is one append-only line of the session journal.
+type journalEntry struct {
+	RelativePath string    `json:"relative_path"`
+	State        TaskState `json:"state"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Session is the resumable record of one backup run: an append-only
+// journal under <target>/.backup-butler/sessions, fsynced at every state
+// transition so a crash mid-write leaves the last complete line intact.
+// This mirrors the client-session pattern long-running transfer tools use
+// to avoid re-doing work after an interruption.
+type Session struct {
+	VersionID string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func sessionsDir(targetDir string) string {
+	return filepath.Join(targetDir, ".backup-butler", "sessions")
+}
+
+func journalPath(targetDir, versionID string) string {
+	return filepath.Join(sessionsDir(targetDir), versionID+".journal")
+}
+
+// NewSession opens (creating if necessary) the journal for versionID,
+// appending to any existing content so a resumed run's history is
+// preserved.
+func NewSession(targetDir, versionID string) (*Session, error) {
+	dir := sessionsDir(targetDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+
+	f, err := os.OpenFile(journalPath(targetDir, versionID), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session journal: %w", err)
+	}
+
+	return &Session{VersionID: versionID, file: f}, nil
+}
+
+// Record appends a state transition for relPath and fsyncs before
+// returning, so the journal never reports a task as further along than
+// what's actually durable on disk.
+func (s *Session) Record(relPath string, state TaskState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := journalEntry{RelativePath: relPath, State: state, Timestamp: time.Now()}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	return s.file.Sync()
+}
+
+// Close releases the journal file handle. It does not delete the journal -
+// that only happens on explicit abandonment or successful completion.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// LoadSessionStates replays versionID's journal and returns the latest
+// recorded state per relative path.
+func LoadSessionStates(targetDir, versionID string) (map[string]TaskState, error) {
+	f, err := os.Open(journalPath(targetDir, versionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]TaskState{}, nil
+		}
+		return nil, fmt.Errorf("failed to open session journal: %w", err)
+	}
+	defer f.Close()
+
+	states := make(map[string]TaskState)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		var entry journalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// A truncated last line means we crashed mid-write; anything
+			// before it is still valid and already recorded.
+			continue
+		}
+		states[entry.RelativePath] = entry.State
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session journal: %w", err)
+	}
+
+	return states, nil
+}
+
+// FindIncompleteSession returns the version ID of the newest session whose
+// journal contains at least one task not yet in TaskVerified or TaskFailed,
+// or "" if every session is complete (or none exist).
+func FindIncompleteSession(targetDir string) (string, error) {
+	dir := sessionsDir(targetDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+
+	var versionIDs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".journal") {
+			versionIDs = append(versionIDs, strings.TrimSuffix(entry.Name(), ".journal"))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versionIDs)))
+
+	for _, versionID := range versionIDs {
+		states, err := LoadSessionStates(targetDir, versionID)
+		if err != nil {
+			return "", err
+		}
+		for _, state := range states {
+			if state != TaskVerified && state != TaskFailed {
+				return versionID, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// AbandonSession deletes versionID's journal, discarding any progress made
+// so a future run starts that version over from scratch.
+func AbandonSession(targetDir, versionID string) error {
+	err := os.Remove(journalPath(targetDir, versionID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to abandon session %s: %w", versionID, err)
+	}
+	return nil
+}
+
+// completeSession removes the journal for a version that finished
+// successfully; there's nothing left to resume.
+func completeSession(targetDir, versionID string) error {
+	return AbandonSession(targetDir, versionID)
+}
@@ -0,0 +1,169 @@
+// internal/backup/sources.go
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+)
+
+// TaskSource enumerates the files a backup run should consider, decoupling
+// task discovery from the directory walk so curated file lists (from
+// --files-from, or a single file streamed over --stdin) can feed the same
+// pipeline as folders_to_backup.
+type TaskSource interface {
+	Tasks(cfg *config.Config) ([]BackupTask, error)
+}
+
+// walkTaskSource is the default source: it walks cfg.FoldersToBackup the
+// same way scanSourceDirectory always has.
+type walkTaskSource struct{}
+
+func (walkTaskSource) Tasks(cfg *config.Config) ([]BackupTask, error) {
+	var tasks []BackupTask
+
+	for _, folder := range cfg.FoldersToBackup {
+		srcPath := filepath.Join(cfg.SourceDirectory, folder)
+
+		err := filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if matchesExcludePattern(cfg.ExcludePatterns, info.Name()) {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(cfg.SourceDirectory, path)
+			if err != nil {
+				return fmt.Errorf("failed to get relative path: %w", err)
+			}
+
+			tasks = append(tasks, BackupTask{
+				SourcePath:   path,
+				DestPath:     filepath.Join(cfg.TargetDirectory, relPath),
+				RelativePath: relPath,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan folder %s: %w", folder, err)
+		}
+	}
+
+	return tasks, nil
+}
+
+// filesFromTaskSource builds the task list from a file containing one path
+// per line (blank lines and `#` comments are skipped), mirroring tools like
+// `rsync --files-from`. Passing "-" reads the list from stdin instead of a
+// file, so curated lists from `find` or `git ls-files` can be piped in
+// directly.
+type filesFromTaskSource struct {
+	listPath string
+}
+
+func (s filesFromTaskSource) Tasks(cfg *config.Config) ([]BackupTask, error) {
+	var r io.Reader
+	if s.listPath == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(s.listPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open files-from list %s: %w", s.listPath, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var tasks []BackupTask
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if matchesExcludePattern(cfg.ExcludePatterns, filepath.Base(line)) {
+			continue
+		}
+
+		var srcPath, relPath string
+		if filepath.IsAbs(line) {
+			srcPath = line
+			rel, err := filepath.Rel(cfg.SourceDirectory, line)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				relPath = filepath.Base(line)
+			} else {
+				relPath = rel
+			}
+		} else {
+			srcPath = filepath.Join(cfg.SourceDirectory, line)
+			relPath = line
+		}
+
+		tasks = append(tasks, BackupTask{
+			SourcePath:   srcPath,
+			DestPath:     filepath.Join(cfg.TargetDirectory, relPath),
+			RelativePath: relPath,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read files-from list: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// stdinTaskSource spools stdin into the target tree as a single file named
+// filename, hashing the content as it streams by since stdin can't be
+// rewound for a second read the way Copier.VerifyCopy normally expects.
+type stdinTaskSource struct {
+	filename string
+}
+
+func (s stdinTaskSource) Tasks(cfg *config.Config) ([]BackupTask, error) {
+	destPath := filepath.Join(cfg.TargetDirectory, s.filename)
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	spoolFile, err := os.CreateTemp("", "backup-butler-stdin-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin spool file: %w", err)
+	}
+	defer spoolFile.Close()
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(spoolFile, hash), os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool stdin: %w", err)
+	}
+
+	return []BackupTask{{
+		SourcePath:          spoolFile.Name(),
+		DestPath:            destPath,
+		RelativePath:        s.filename,
+		PrecomputedChecksum: hex.EncodeToString(hash.Sum(nil)),
+		PrecomputedSize:     written,
+	}}, nil
+}
+
+func matchesExcludePattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package bisync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/jack-sneddon/backup-butler/internal/storage"
+	"github.com/jack-sneddon/backup-butler/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// side1Tag and side2Tag are the BackupVersion.Side values bisync records
+// for the two halves of a pair, named after the request's path1/path2
+// conflict-policy vocabulary rather than "source"/"target" - neither side
+// is the authority here the way source/target are for sync.
+const (
+	side1Tag = "path1"
+	side2Tag = "path2"
+)
+
+func NewBisyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bisync",
+		Short: "Bidirectionally reconcile changes between source and target",
+		Long: `Bisync reconciles changes made independently on both sides of a pair
+since the last run, instead of mirroring one direction the way sync does.
+Unilateral changes propagate to the side that didn't change; changes made
+identically on both sides are skipped; changes that diverge are conflicts,
+settled by --conflict-policy.`,
+		RunE: runBisync,
+	}
+
+	cmd.Flags().Bool("resync", false, "seed the initial snapshot for both sides without acting on any difference")
+	cmd.Flags().Float64("max-delete", 10, "abort if more than this percent of either side's files would be deleted")
+	cmd.Flags().String("conflict-policy", string(PolicyNewer), "how to resolve a path changed differently on both sides (newer|larger|path1|path2|rename-both)")
+
+	return cmd
+}
+
+func runBisync(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	resync, _ := cmd.Flags().GetBool("resync")
+	maxDeletePercent, _ := cmd.Flags().GetFloat64("max-delete")
+	policy := ConflictPolicy(cmd.Flag("conflict-policy").Value.String())
+
+	path1 := cfg.Source
+	path2 := cfg.Target
+
+	mgr, err := version.NewManager(path2, cfg.BufferSize)
+	if err != nil {
+		return fmt.Errorf("failed to open bisync version store: %w", err)
+	}
+
+	current1, err := scanSide(path1, cfg.Exclude)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", path1, err)
+	}
+	current2, err := scanSide(path2, cfg.Exclude)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", path2, err)
+	}
+
+	if resync {
+		logger.Info("Bisync --resync: seeding initial snapshot without acting on differences")
+		return recordSnapshots(mgr, cfg, path1, path2, current1, current2)
+	}
+
+	last1, err := mgr.LatestVersionForSide(side1Tag)
+	if err != nil {
+		return fmt.Errorf("failed to load previous %s snapshot: %w", side1Tag, err)
+	}
+	last2, err := mgr.LatestVersionForSide(side2Tag)
+	if err != nil {
+		return fmt.Errorf("failed to load previous %s snapshot: %w", side2Tag, err)
+	}
+	if last1 == nil || last2 == nil {
+		return fmt.Errorf("no prior bisync snapshot found - run with --resync first")
+	}
+
+	snapshot1 := snapshotFromVersion(last1)
+	snapshot2 := snapshotFromVersion(last2)
+
+	changes1 := Classify(snapshot1, current1)
+	changes2 := Classify(snapshot2, current2)
+
+	if err := checkMaxDelete(changes1, len(snapshot1), maxDeletePercent); err != nil {
+		return fmt.Errorf("%s: %w", path1, err)
+	}
+	if err := checkMaxDelete(changes2, len(snapshot2), maxDeletePercent); err != nil {
+		return fmt.Errorf("%s: %w", path2, err)
+	}
+
+	actions := Reconcile(current1, current2, changes1, changes2)
+
+	copier := storage.NewCopier(storage.NewManager(path2, cfg.BufferSize, cfg), cfg.BufferSize, 1)
+	ctx := context.Background()
+
+	for path, action := range actions {
+		if err := applyAction(ctx, copier, policy, path1, path2, path, action, current1, current2); err != nil {
+			logger.Error("Bisync action failed", "path", path, "action", action.String(), "error", err)
+			return fmt.Errorf("failed to reconcile %s: %w", path, err)
+		}
+	}
+
+	final1, err := scanSide(path1, cfg.Exclude)
+	if err != nil {
+		return fmt.Errorf("failed to rescan %s: %w", path1, err)
+	}
+	final2, err := scanSide(path2, cfg.Exclude)
+	if err != nil {
+		return fmt.Errorf("failed to rescan %s: %w", path2, err)
+	}
+
+	return recordSnapshots(mgr, cfg, path1, path2, final1, final2)
+}
+
+// checkMaxDelete aborts the run if classifying current against baseline
+// (which had baselineCount paths) would delete more than maxPercent of
+// them - the guard against a side mounted empty and read back as "every
+// file disappeared".
+func checkMaxDelete(changes map[string]ChangeType, baselineCount int, maxPercent float64) error {
+	if baselineCount == 0 {
+		return nil
+	}
+
+	var deleted int
+	for _, c := range changes {
+		if c == Deleted {
+			deleted++
+		}
+	}
+
+	percent := float64(deleted) / float64(baselineCount) * 100
+	if percent > maxPercent {
+		return fmt.Errorf("refusing to delete %d/%d files (%.1f%%), exceeds --max-delete=%.1f%%", deleted, baselineCount, percent, maxPercent)
+	}
+	return nil
+}
+
+func applyAction(ctx context.Context, copier *storage.Copier, policy ConflictPolicy, path1, path2, rel string, action Action, current1, current2 Snapshot) error {
+	full1 := filepath.Join(path1, rel)
+	full2 := filepath.Join(path2, rel)
+
+	switch action {
+	case ActionNone:
+		return nil
+	case ActionPropagateTo2:
+		_, err := copier.Copy(ctx, full1, full2)
+		return err
+	case ActionPropagateTo1:
+		_, err := copier.Copy(ctx, full2, full1)
+		return err
+	case ActionDeleteFrom1:
+		return removeIfExists(full1)
+	case ActionDeleteFrom2:
+		return removeIfExists(full2)
+	case ActionConflict:
+		resolution, err := ResolveConflict(policy, rel, current1[rel], current2[rel])
+		if err != nil {
+			return err
+		}
+		return applyResolution(ctx, copier, full1, full2, resolution)
+	default:
+		return fmt.Errorf("unhandled action %v for %s", action, rel)
+	}
+}
+
+func applyResolution(ctx context.Context, copier *storage.Copier, full1, full2 string, resolution Resolution) error {
+	switch resolution.Winner {
+	case "1":
+		_, err := copier.Copy(ctx, full1, full2)
+		return err
+	case "2":
+		_, err := copier.Copy(ctx, full2, full1)
+		return err
+	}
+
+	// rename-both: keep each side's own version under a .conflictN
+	// suffix, rather than picking a winner. runBisync rescans both sides
+	// after acting and records those scans as the new snapshots, so the
+	// next run sees each renamed path as Unchanged on the side that has
+	// it and does not copy it to the other side.
+	if resolution.RenameTo1 != "" {
+		if err := os.Rename(full1, filepath.Join(filepath.Dir(full1), filepath.Base(resolution.RenameTo1))); err != nil {
+			return err
+		}
+	}
+	if resolution.RenameTo2 != "" {
+		if err := os.Rename(full2, filepath.Join(filepath.Dir(full2), filepath.Base(resolution.RenameTo2))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeIfExists(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// recordSnapshots persists current1 and current2 as new BackupVersions
+// tagged side1Tag/side2Tag, so the next run's LatestVersionForSide finds
+// them. The two share a timestamp but need distinct IDs, since a version
+// ID is also its filename under .versions.
+func recordSnapshots(mgr *version.Manager, cfg *config.Config, root1, root2 string, current1, current2 Snapshot) error {
+	id := time.Now().Format("20060102-150405")
+
+	if err := recordSnapshot(mgr, cfg, id+"-"+side1Tag, side1Tag, root1, current1); err != nil {
+		return err
+	}
+	return recordSnapshot(mgr, cfg, id+"-"+side2Tag, side2Tag, root2, current2)
+}
+
+// recordSnapshot writes snapshot as side's new BackupVersion. Every entry
+// gets a checksum computed now if it doesn't already have one, and the
+// checksum is stored with the snapshot. The next run's Classify compares
+// that snapshot against a fresh scan that carries no checksums, so the
+// stored checksum does not take part in the comparison, which stays on
+// size and mod time.
+func recordSnapshot(mgr *version.Manager, cfg *config.Config, id, side, root string, snapshot Snapshot) error {
+	v := mgr.StartVersionWithID(cfg, id)
+	v.Side = side
+
+	for path, entry := range snapshot {
+		if entry.Checksum == "" {
+			if sum, err := checksumFile(filepath.Join(root, path)); err == nil {
+				entry.Checksum = sum
+			}
+		}
+		if err := mgr.RecordFile(path, "unchanged", entry.Size, entry.ModTime, entry.Checksum, nil); err != nil {
+			return fmt.Errorf("failed to record %s snapshot entry %s: %w", side, path, err)
+		}
+	}
+
+	return mgr.CompleteVersion()
+}
@@ -0,0 +1,36 @@
+package bisync
+
+import "fmt"
+
+// Resolution is how a Conflict action was settled. Winner is "1" or "2"
+// for every policy except rename-both, where both paths are kept under
+// RenameTo1/RenameTo2 and Winner is empty.
+type Resolution struct {
+	Winner               string
+	RenameTo1, RenameTo2 string
+}
+
+// ResolveConflict applies policy to path, whose side1 and side2 Entries
+// changed differently since the last run.
+func ResolveConflict(policy ConflictPolicy, path string, side1, side2 Entry) (Resolution, error) {
+	switch policy {
+	case PolicyNewer:
+		if side1.ModTime.After(side2.ModTime) {
+			return Resolution{Winner: "1"}, nil
+		}
+		return Resolution{Winner: "2"}, nil
+	case PolicyLarger:
+		if side1.Size >= side2.Size {
+			return Resolution{Winner: "1"}, nil
+		}
+		return Resolution{Winner: "2"}, nil
+	case PolicyPath1:
+		return Resolution{Winner: "1"}, nil
+	case PolicyPath2:
+		return Resolution{Winner: "2"}, nil
+	case PolicyRenameBoth:
+		return Resolution{RenameTo1: path + ".conflict1", RenameTo2: path + ".conflict2"}, nil
+	default:
+		return Resolution{}, fmt.Errorf("unknown conflict policy %q", policy)
+	}
+}
@@ -0,0 +1,30 @@
+package bisync
+
+// Classify compares current, a fresh scan of one side, against last, that
+// side's snapshot as of the previous run, and returns the ChangeType of
+// every path that changed. A path present in both but different is
+// Modified; present only in current is New; present only in last is
+// Deleted. A path present in both with equal Entries is Unchanged and is
+// left out of the returned map, so an absent path is equivalent to
+// Unchanged for Reconcile's purposes.
+func Classify(last, current Snapshot) map[string]ChangeType {
+	changes := make(map[string]ChangeType, len(current))
+
+	for path, curEntry := range current {
+		lastEntry, existed := last[path]
+		switch {
+		case !existed:
+			changes[path] = New
+		case !curEntry.Equal(lastEntry):
+			changes[path] = Modified
+		}
+	}
+
+	for path := range last {
+		if _, stillThere := current[path]; !stillThere {
+			changes[path] = Deleted
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,74 @@
+package bisync
+
+// Reconcile combines side1 and side2's independent classifications
+// (from Classify, against each side's own last snapshot) and their
+// current Snapshots into a single Action per path: a unilateral change
+// propagates to the side that didn't change, a mutual change that left
+// both sides with identical content is skipped, and a mutual change that
+// left them different is a conflict for ResolveConflict to settle.
+func Reconcile(side1, side2 Snapshot, changes1, changes2 map[string]ChangeType) map[string]Action {
+	paths := make(map[string]struct{}, len(changes1)+len(changes2))
+	for p := range changes1 {
+		paths[p] = struct{}{}
+	}
+	for p := range changes2 {
+		paths[p] = struct{}{}
+	}
+
+	actions := make(map[string]Action, len(paths))
+	for p := range paths {
+		// Unchanged is the zero value when a path has no entry in one
+		// side's classification - Classify only records paths it has an
+		// opinion about.
+		actions[p] = reconcileOne(p, changes1[p], changes2[p], side1, side2)
+	}
+	return actions
+}
+
+func reconcileOne(path string, c1, c2 ChangeType, side1, side2 Snapshot) Action {
+	switch {
+	case c1 == Unchanged && c2 == Unchanged:
+		return ActionNone
+
+	case c1 != Unchanged && c2 == Unchanged:
+		return propagateFrom(1, c1)
+	case c1 == Unchanged && c2 != Unchanged:
+		return propagateFrom(2, c2)
+
+	// Both sides changed, and in the same way with the same resulting
+	// content (e.g. both deleted, or both edited to identical bytes) -
+	// nothing to reconcile.
+	case c1 == c2 && sideEntriesEqual(path, side1, side2):
+		return ActionNone
+
+	// Both sides changed, but not to the same result - a genuine
+	// conflict for ResolveConflict to settle.
+	default:
+		return ActionConflict
+	}
+}
+
+func sideEntriesEqual(path string, side1, side2 Snapshot) bool {
+	e1, ok1 := side1[path]
+	e2, ok2 := side2[path]
+	if ok1 != ok2 {
+		return false // one side deleted it, the other still has it
+	}
+	if !ok1 {
+		return true // deleted on both
+	}
+	return e1.Equal(e2)
+}
+
+func propagateFrom(side int, c ChangeType) Action {
+	if c == Deleted {
+		if side == 1 {
+			return ActionDeleteFrom2
+		}
+		return ActionDeleteFrom1
+	}
+	if side == 1 {
+		return ActionPropagateTo2
+	}
+	return ActionPropagateTo1
+}
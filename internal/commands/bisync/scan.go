@@ -0,0 +1,79 @@
+package bisync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// scanSide walks root and returns a Snapshot of every regular file under
+// it, keyed by its path relative to root. Checksums aren't computed here
+// - hashing every file on every run would defeat the point of a cheap
+// scan - and Entry.Equal only compares checksums when both entries carry one,
+// so Classify and sideEntriesEqual compare a fresh scan by size and mod
+// time alone.
+func scanSide(root string, excludePatterns []string) (Snapshot, error) {
+	snapshot := make(Snapshot)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return relErr
+		}
+		if matchesAny(rel, excludePatterns) {
+			return nil
+		}
+
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			return infoErr
+		}
+
+		snapshot[rel] = Entry{Size: info.Size(), ModTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snapshot, nil
+		}
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func matchesAny(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checksumFile returns path's full SHA-256 digest. Its only caller is
+// recordSnapshot, which stores the digest in the side's snapshot; no
+// comparison of a fresh scan consults it.
+func checksumFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,105 @@
+// Package bisync reconciles changes made independently on both sides of a
+// backup pair since the last run, rather than mirroring one direction the
+// way sync does. Each side keeps its own snapshot (a version.BackupVersion
+// tagged with its Side) of what bisync last saw there; the next run scans
+// both trees, classifies every path against its side's snapshot, and
+// combines the two classifications into a single action per path.
+package bisync
+
+import "time"
+
+// ChangeType classifies a single path's state on one side relative to
+// that side's last recorded snapshot.
+type ChangeType int
+
+const (
+	Unchanged ChangeType = iota
+	Modified
+	New
+	Deleted
+)
+
+func (c ChangeType) String() string {
+	switch c {
+	case Unchanged:
+		return "unchanged"
+	case Modified:
+		return "modified"
+	case New:
+		return "new"
+	case Deleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// Entry is one path's metadata, either as recorded in a side's snapshot
+// or as observed by the current scan.
+type Entry struct {
+	Size     int64
+	ModTime  time.Time
+	Checksum string
+}
+
+// Equal reports whether e and other describe the same content. Checksum
+// is only compared when both sides have one; otherwise size and mod time
+// alone decide it, the same tradeoff MetadataCompare makes elsewhere in
+// this codebase.
+func (e Entry) Equal(other Entry) bool {
+	if e.Size != other.Size {
+		return false
+	}
+	if e.Checksum != "" && other.Checksum != "" {
+		return e.Checksum == other.Checksum
+	}
+	return e.ModTime.Equal(other.ModTime)
+}
+
+// Snapshot is a side's full path -> Entry set, either the last run's
+// recorded state or the current scan.
+type Snapshot map[string]Entry
+
+// ConflictPolicy decides which side wins when a path changed on both
+// sides since the last run in ways that don't match.
+type ConflictPolicy string
+
+const (
+	PolicyNewer      ConflictPolicy = "newer"
+	PolicyLarger     ConflictPolicy = "larger"
+	PolicyPath1      ConflictPolicy = "path1"
+	PolicyPath2      ConflictPolicy = "path2"
+	PolicyRenameBoth ConflictPolicy = "rename-both"
+)
+
+// Action is what bisync decided to do with one path after combining both
+// sides' classifications.
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionPropagateTo1
+	ActionPropagateTo2
+	ActionDeleteFrom1
+	ActionDeleteFrom2
+	ActionConflict
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionNone:
+		return "none"
+	case ActionPropagateTo1:
+		return "propagate->path1"
+	case ActionPropagateTo2:
+		return "propagate->path2"
+	case ActionDeleteFrom1:
+		return "delete<-path1"
+	case ActionDeleteFrom2:
+		return "delete<-path2"
+	case ActionConflict:
+		return "conflict"
+	default:
+		return "unknown"
+	}
+}
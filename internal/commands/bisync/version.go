@@ -0,0 +1,25 @@
+package bisync
+
+import "github.com/jack-sneddon/backup-butler/internal/version"
+
+// snapshotFromVersion rebuilds the Snapshot bisync saw the last time it
+// ran on one side from that side's recorded BackupVersion. A nil v (no
+// prior version for this side, or the Side was never seen before) yields
+// an empty Snapshot, which Classify treats as "every current path is New".
+func snapshotFromVersion(v *version.BackupVersion) Snapshot {
+	snapshot := make(Snapshot)
+	if v == nil {
+		return snapshot
+	}
+	for _, change := range v.Changes {
+		if change.Action == "deleted" {
+			continue
+		}
+		snapshot[change.Path] = Entry{
+			Size:     change.Size,
+			ModTime:  change.Timestamp,
+			Checksum: change.Checksum,
+		}
+	}
+	return snapshot
+}
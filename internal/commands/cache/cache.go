@@ -0,0 +1,142 @@
+// internal/commands/cache/cache.go
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+	storagecache "github.com/jack-sneddon/backup-butler/internal/core/storage/cache"
+	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the persistent checksum cache",
+	}
+
+	cmd.AddCommand(newPruneCmd())
+	cmd.AddCommand(newGCCmd())
+	cmd.AddCommand(newStatsCmd())
+
+	return cmd
+}
+
+func newPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Evict least-recently-used checksum cache entries down to a size budget",
+		Long: `Prune evicts the checksum cache's least-recently-accessed entries, oldest
+first, until the cache's serialized size is at or under --keep-storage.`,
+		RunE: runPrune,
+	}
+
+	cmd.Flags().Int64("keep-storage", 0, "target cache size in bytes to prune down to (0 removes every entry)")
+
+	return cmd
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	keepBytes, _ := cmd.Flags().GetInt64("keep-storage")
+
+	store, err := storagecache.Open(cfg.Target)
+	if err != nil {
+		return fmt.Errorf("failed to open checksum cache: %w", err)
+	}
+
+	removed, err := store.Prune(keepBytes)
+	if err != nil {
+		return fmt.Errorf("failed to prune checksum cache: %w", err)
+	}
+
+	logger.Info("Checksum cache pruned", "keepStorageBytes", keepBytes, "entriesRemoved", removed)
+	fmt.Printf("Removed %d cache entr%s\n", removed, plural(removed))
+
+	return nil
+}
+
+func newGCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove checksum cache entries not consulted within a retention window",
+		RunE:  runGC,
+	}
+
+	cmd.Flags().Duration("older-than", 30*24*time.Hour, "remove entries last consulted before this long ago")
+
+	return cmd
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+
+	store, err := storagecache.Open(cfg.Target)
+	if err != nil {
+		return fmt.Errorf("failed to open checksum cache: %w", err)
+	}
+
+	removed, err := store.GC(olderThan)
+	if err != nil {
+		return fmt.Errorf("failed to gc checksum cache: %w", err)
+	}
+
+	logger.Info("Checksum cache gc complete", "olderThan", olderThan, "entriesRemoved", removed)
+	fmt.Printf("Removed %d cache entr%s older than %s\n", removed, plural(removed), olderThan)
+
+	return nil
+}
+
+func newStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Report the checksum cache's entry count and on-disk size",
+		RunE:  runStats,
+	}
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storagecache.Open(cfg.Target)
+	if err != nil {
+		return fmt.Errorf("failed to open checksum cache: %w", err)
+	}
+
+	entries, bytes, err := store.Size()
+	if err != nil {
+		return fmt.Errorf("failed to measure checksum cache: %w", err)
+	}
+
+	fmt.Printf("Entries: %d\n", entries)
+	fmt.Printf("Size:    %d bytes\n", bytes)
+
+	return nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
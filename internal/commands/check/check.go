@@ -2,14 +2,21 @@
 package check
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/config"
 	"github.com/jack-sneddon/backup-butler/internal/logger"
 	"github.com/jack-sneddon/backup-butler/internal/scan"
 	"github.com/jack-sneddon/backup-butler/internal/types"
+	"github.com/jack-sneddon/backup-butler/internal/ui/cli/formatter"
+	"github.com/jack-sneddon/backup-butler/internal/ui/termstatus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 func NewCheckCmd() *cobra.Command {
@@ -26,6 +33,9 @@ Validation levels:
 
 	cmd.Flags().StringP("level", "l", "standard", "validation level (quick|standard|deep)")
 	cmd.Flags().StringP("output", "o", "text", "output format (text|csv|html)")
+	cmd.Flags().String("output-file", "", "write formatted results here instead of stdout")
+	cmd.Flags().Bool("no-unicode-normalization", false, "treat paths differing only in Unicode normalization form as different files")
+	cmd.Flags().Duration("timeout", 0, "abort the scan/compare if it runs longer than this (0 disables the timeout)")
 
 	return cmd
 }
@@ -106,14 +116,26 @@ func runCheck(cmd *cobra.Command, args []string) error {
 			"default", cfg.Validation.Level)
 	}
 
+	noUnicodeNormalization, _ := cmd.Flags().GetBool("no-unicode-normalization")
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Create scanner options from config
 	opts := &scan.ScannerOptions{
-		ExcludePatterns:  cfg.Exclude,
-		IncludeFolders:   cfg.Folders,
-		BufferSize:       cfg.Comparison.BufferSize,
-		MaxDepth:         -1,
-		Level:            types.ValidationLevel(level),
-		ValidationConfig: cfg.Validation,
+		ExcludePatterns:             cfg.Exclude,
+		IncludeFolders:              cfg.Folders,
+		BufferSize:                  cfg.Comparison.BufferSize,
+		MaxDepth:                    -1,
+		Level:                       types.ValidationLevel(level),
+		ValidationConfig:            cfg.Validation,
+		DisableUnicodeNormalization: noUnicodeNormalization,
 	}
 
 	logger.Debug("check.go:runCheck() - Scanner options created",
@@ -121,120 +143,98 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	scanner := scan.NewScanner(opts)
 
-	// Start progress display
-	doneChan := make(chan bool)
-	go displayProgress(scanner.GetProgress(), doneChan)
+	// Start progress display. progCtx is its own cancellation lever, separate
+	// from ctx, so a clean scan completion tears the display down the same
+	// way a signal-triggered cancellation does; errgroup ties the display
+	// goroutine's lifetime to whichever happens first.
+	progCtx, stopProgress := context.WithCancel(ctx)
+	status := termstatus.New(os.Stdout)
+	g, gctx := errgroup.WithContext(progCtx)
+	g.Go(func() error {
+		displayProgress(gctx, status, scanner.GetProgress())
+		return nil
+	})
 
 	// Perform the scan
-	progress, err := scanner.Scan(cfg.Source)
+	progress, err := scanner.Scan(ctx, cfg.Source)
+	stopProgress()
+	_ = g.Wait()
+	status.Stop()
 	if err != nil {
-		doneChan <- true
 		return err
 	}
 
-	// Print final summary
-	doneChan <- true
-	printSummary(cfg, progress)
-
 	// Perform comparison based on validation level
-	comparisons, err := scanner.Compare(cfg.Source, cfg.Target)
+	comparisons, err := scanner.Compare(ctx, cfg.Source, cfg.Target)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	resultsFormatter, err := resultsFormatterFor(output)
 	if err != nil {
 		return err
 	}
 
-	// Print comparison results
-	printResults(comparisons)
+	out := cmd.OutOrStdout()
+	if outputFile, _ := cmd.Flags().GetString("output-file"); outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
 
-	return nil
+	return resultsFormatter.FormatResults(out, comparisons, progress, cfg)
 }
 
-func displayProgress(progress *scan.Progress, done chan bool) {
+// resultsFormatterFor picks the formatter.ResultsFormatter matching the
+// --output flag's value.
+func resultsFormatterFor(output string) (formatter.ResultsFormatter, error) {
+	switch output {
+	case "", "text":
+		return formatter.NewTextResultsFormatter(), nil
+	case "csv":
+		return formatter.NewCSVResultsFormatter(), nil
+	case "html":
+		return formatter.NewHTMLResultsFormatter(), nil
+	default:
+		return nil, fmt.Errorf("invalid output format: %s", output)
+	}
+}
+
+// displayProgress redraws a multi-line status block - current directory,
+// scan percentage, file and byte counts - via status until ctx is done,
+// instead of the single overwritten line the old ticker loop printed.
+func displayProgress(ctx context.Context, status *termstatus.Status, progress *scan.Progress) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-done:
-			fmt.Print("\033[2K\r") // Clear the line
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			if progress.TotalBytes > 0 {
 				percentage := float64(progress.ProcessedBytes) / float64(progress.TotalBytes) * 100
-				fmt.Printf("\033[2K\r%s - %.1f%% (%d/%d files, %s/%s)",
-					progress.CurrentDir,
-					percentage,
-					progress.ScannedFiles,
-					progress.TotalFiles,
-					formatBytes(progress.ProcessedBytes),
-					formatBytes(progress.TotalBytes))
+				status.Update([]string{
+					fmt.Sprintf("scanning %s", progress.CurrentDir),
+					fmt.Sprintf("%.1f%% - %d/%d files, %s/%s",
+						percentage,
+						progress.ScannedFiles,
+						progress.TotalFiles,
+						formatBytes(progress.ProcessedBytes),
+						formatBytes(progress.TotalBytes)),
+				})
 			}
 		}
 	}
 }
 
-func printSummary(cfg *config.Config, progress *scan.Progress) {
-	fmt.Printf("\nScan Results:\n")
-	fmt.Printf("├── Locations\n")
-	fmt.Printf("│   ├── Source: %s\n", cfg.Source)
-	fmt.Printf("│   └── Target: %s\n", cfg.Target)
-
-	fmt.Printf("├── Summary\n")
-	fmt.Printf("│   ├── Directories: %d\n", progress.ScannedDirs)
-	fmt.Printf("│   ├── Files: %d\n", progress.ScannedFiles)
-	fmt.Printf("│   ├── Total Size: %s\n", formatBytes(progress.TotalBytes))
-	if progress.ExcludedFiles > 0 || progress.ExcludedDirs > 0 {
-		fmt.Printf("│   ├── Excluded Files: %d\n", progress.ExcludedFiles)
-		fmt.Printf("│   └── Excluded Directories: %d\n", progress.ExcludedDirs)
-	} else {
-		fmt.Printf("│   └── No Exclusions\n")
-	}
-
-	if len(progress.Errors) > 0 {
-		fmt.Printf("├── Scan Errors\n")
-		for i, err := range progress.Errors {
-			if i == len(progress.Errors)-1 {
-				fmt.Printf("│   └── %s\n", err)
-			} else {
-				fmt.Printf("│   ├── %s\n", err)
-			}
-		}
-	}
-}
-
-func printResults(comparisons []*scan.FileComparison) {
-	var matches, new, missing, differs, errors int
-
-	fmt.Printf("└── File Status\n")
-	for _, comp := range comparisons {
-		switch comp.Status {
-		case scan.StatusMatch:
-			matches++
-		case scan.StatusNew:
-			new++
-		case scan.StatusMissing:
-			missing++
-		case scan.StatusDiffer:
-			differs++
-		case scan.StatusError:
-			errors++
-		}
-		// Add validation level to output
-		levelStr := ""
-		if comp.Level != "" {
-			levelStr = fmt.Sprintf(" [%s]", string(comp.Level))
-		}
-		fmt.Printf("    %c %s%s\n", comp.Status, comp.Path, levelStr)
-	}
-
-	// Print statistics
-	fmt.Printf("\nResults Summary:\n")
-	fmt.Printf("├── Matched:  %d files\n", matches)
-	fmt.Printf("├── New:      %d files\n", new)
-	fmt.Printf("├── Missing:  %d files\n", missing)
-	fmt.Printf("├── Modified: %d files\n", differs)
-	fmt.Printf("└── Errors:   %d files\n", errors)
-}
-
 func isValidLevel(level string) bool {
 	return types.IsValidLevel(level)
 }
@@ -0,0 +1,65 @@
+// internal/commands/gc/gc.go
+package gc
+
+import (
+	"fmt"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/jack-sneddon/backup-butler/internal/version"
+	"github.com/spf13/cobra"
+)
+
+func NewGCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Reclaim target chunk store space no surviving version references",
+		Long: `GC walks every .versions/*.json manifest, computes the set of chunk
+hashes still referenced by a surviving version, and removes anything in the
+target's chunk store that isn't. Unlike "verify --deep", it doesn't re-hash
+surviving chunks against their digest, so it's cheap enough to run after
+every backup.`,
+		RunE: runGC,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "report what would be removed without removing it")
+	cmd.Flags().Int("keep-recent", 0, "only protect chunks referenced by the N most recently started versions (0 protects all versions)")
+
+	return cmd
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	keepRecent, _ := cmd.Flags().GetInt("keep-recent")
+
+	mgr, err := version.NewManager(cfg.Target, cfg.BufferSize)
+	if err != nil {
+		return fmt.Errorf("failed to open version store: %w", err)
+	}
+
+	report, err := mgr.GC(keepRecent, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to gc chunk store: %w", err)
+	}
+
+	logger.Info("Chunk store gc complete",
+		"dryRun", dryRun,
+		"keepRecent", keepRecent,
+		"orphaned", len(report.OrphanedChunks),
+		"bytesReclaimed", report.BytesReclaimed)
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	fmt.Printf("%s %d orphaned chunk(s) (%d bytes)\n", verb, len(report.OrphanedChunks), report.BytesReclaimed)
+
+	return nil
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jack-sneddon/backup-butler/internal/commands/bisync"
+	"github.com/jack-sneddon/backup-butler/internal/commands/cache"
 	"github.com/jack-sneddon/backup-butler/internal/commands/check"
+	"github.com/jack-sneddon/backup-butler/internal/commands/gc"
 	"github.com/jack-sneddon/backup-butler/internal/commands/sync" // Updated import
+	"github.com/jack-sneddon/backup-butler/internal/commands/verify"
 	"github.com/jack-sneddon/backup-butler/internal/commands/version"
 	"github.com/jack-sneddon/backup-butler/internal/config"
 	"github.com/jack-sneddon/backup-butler/internal/logger"
@@ -76,4 +80,8 @@ func init() {
 	rootCmd.AddCommand(version.NewVersionCmd())
 	rootCmd.AddCommand(check.NewCheckCmd())
 	rootCmd.AddCommand(sync.NewSyncCmd())
+	rootCmd.AddCommand(bisync.NewBisyncCmd())
+	rootCmd.AddCommand(verify.NewVerifyCmd())
+	rootCmd.AddCommand(gc.NewGCCmd())
+	rootCmd.AddCommand(cache.NewCacheCmd())
 }
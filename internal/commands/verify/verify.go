@@ -0,0 +1,69 @@
+// internal/commands/verify/verify.go
+package verify
+
+import (
+	"fmt"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/jack-sneddon/backup-butler/internal/version"
+	"github.com/spf13/cobra"
+)
+
+func NewVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the target's version chunk store",
+		Long: `Verify checks the content-addressed chunk store backing the target's
+version history. With --deep, it re-hashes every chunk against its digest
+and prunes any chunk no surviving version references, reporting corruption
+it finds rather than deleting data a version still depends on.`,
+		RunE: runVerify,
+	}
+
+	cmd.Flags().Bool("deep", false, "re-hash every chunk store entry and prune orphans")
+
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	cfgFile := cmd.Root().PersistentFlags().Lookup("config").Value.String()
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	deep, _ := cmd.Flags().GetBool("deep")
+	if !deep {
+		return fmt.Errorf("verify currently only supports --deep")
+	}
+
+	mgr, err := version.NewManager(cfg.Target, cfg.BufferSize)
+	if err != nil {
+		return fmt.Errorf("failed to open version store: %w", err)
+	}
+
+	report, err := mgr.VerifyDeep()
+	if err != nil {
+		return fmt.Errorf("failed to verify chunk store: %w", err)
+	}
+
+	logger.Info("Chunk store verified",
+		"checked", report.ChunksChecked,
+		"corrupt", len(report.CorruptChunks),
+		"missing", len(report.MissingChunks),
+		"orphaned", len(report.OrphanedChunks),
+		"bytesReclaimed", report.BytesReclaimed)
+
+	fmt.Printf("Chunks checked:    %d\n", report.ChunksChecked)
+	fmt.Printf("Corrupt chunks:    %d\n", len(report.CorruptChunks))
+	fmt.Printf("Missing chunks:    %d\n", len(report.MissingChunks))
+	fmt.Printf("Orphaned chunks:   %d (%d bytes reclaimed)\n", len(report.OrphanedChunks), report.BytesReclaimed)
+
+	if len(report.CorruptChunks) > 0 {
+		return fmt.Errorf("found %d corrupt chunk(s) - see log for detail", len(report.CorruptChunks))
+	}
+
+	return nil
+}
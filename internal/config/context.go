@@ -0,0 +1,83 @@
+// internal/config/context.go
+package config
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Info is the set of tunables a call reads from its context instead of a
+// package-level global (internal/logger's Debug/Info/Warn/Error) or a
+// field threaded through a constructor (Copier.bufferSize, Manager's
+// retry settings) - the deglobalisation rclone's fs.Config -> fs.GetConfig
+// did. A caller that wants to override one knob for a subtree clones the
+// ambient Info with AddConfig, mutates the clone, and passes the returned
+// context onward; every other goroutine still reading the parent context
+// sees the unmodified original.
+type Info struct {
+	LogLevel       string
+	DryRun         bool
+	BandwidthLimit int64 // bytes/sec; zero means unlimited
+	RetryAttempts  int
+	RetryDelay     time.Duration
+	BufferSize     int
+	Logger         *slog.Logger
+}
+
+type contextKey struct{}
+
+// DefaultInfo returns the Info FromContext falls back to when none has
+// been set on the context, matching DefaultConfig's tunables. Logger is
+// left nil; a caller that needs one should set it explicitly, the way
+// InfoFromConfig does.
+func DefaultInfo() *Info {
+	return &Info{
+		LogLevel:      "normal",
+		RetryAttempts: 3,
+		RetryDelay:    time.Second,
+		BufferSize:    32 * 1024,
+	}
+}
+
+// InfoFromConfig builds an Info from a loaded Config and a logger, the
+// usual way a command seeds the root context before calling into the
+// copy/compare/processor layers.
+func InfoFromConfig(cfg *Config, logger *slog.Logger) *Info {
+	retryDelay, err := time.ParseDuration(cfg.RetryDelay)
+	if err != nil {
+		retryDelay = time.Second
+	}
+	return &Info{
+		RetryAttempts: cfg.RetryAttempts,
+		RetryDelay:    retryDelay,
+		BufferSize:    cfg.BufferSize,
+		Logger:        logger,
+	}
+}
+
+// NewContext returns a copy of ctx carrying info.
+func NewContext(ctx context.Context, info *Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the Info carried on ctx, or DefaultInfo() if the
+// context never had one attached - so code that forgets to seed the
+// context, or a call from a test using context.Background(), still gets
+// sane tunables instead of a nil dereference.
+func FromContext(ctx context.Context) *Info {
+	if info, ok := ctx.Value(contextKey{}).(*Info); ok && info != nil {
+		return info
+	}
+	return DefaultInfo()
+}
+
+// AddConfig shallow-clones ctx's current Info and returns a context
+// carrying the clone, along with the clone itself for the caller to
+// mutate before passing the context on - e.g. a per-folder YAML override
+// that should raise RetryAttempts for just that subtree without racing
+// sibling goroutines still reading the parent context's Info.
+func AddConfig(ctx context.Context) (context.Context, *Info) {
+	clone := *FromContext(ctx)
+	return NewContext(ctx, &clone), &clone
+}
@@ -39,9 +39,10 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("target_directory is required")
 	}
 
-	if len(config.FoldersToBackup) == 0 {
-		return fmt.Errorf("folders_to_backup must contain at least one folder")
-	}
+	// folders_to_backup may be left empty here when the CLI is going to
+	// point the run at a --files-from list or --stdin instead; those flags
+	// are applied to the loaded Config after LoadConfig returns, so the
+	// full "do we have any task source" check happens in cmd/main.go.
 
 	// Validate source directory exists
 	if _, err := os.Stat(config.SourceDirectory); err != nil {
@@ -45,6 +45,21 @@ type Config struct {
 	RetryDelay      string   `yaml:"retry_delay"`
 	ExcludePatterns []string `yaml:"exclude_patterns"`
 	LogLevel        LogLevel `yaml:"log_level"`
+
+	// FilesFrom and StdinFilename select an alternate TaskSource instead of
+	// walking FoldersToBackup; set via --files-from/--stdin-filename rather
+	// than the config file, so they're left out of DefaultConfig.
+	FilesFrom     string `yaml:"-"`
+	StdinFilename string `yaml:"-"`
+
+	// ChunkCacheSize bounds the shared content-defined chunk cache (see
+	// internal/storage/chunker) in bytes. Zero disables the cache.
+	ChunkCacheSize int64 `yaml:"chunk_cache_size"`
+
+	// DeepVerifyPatterns lists glob patterns (supporting `**`) whose
+	// matches get a full SHA-256 comparison during validation instead of
+	// the default 32KB probe. Extended per-invocation with --deep-verify.
+	DeepVerifyPatterns []string `yaml:"deep_verify_patterns"`
 }
 
 type ConfigOptions struct {
@@ -56,11 +71,12 @@ type ConfigOptions struct {
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		Concurrency:   2,
-		BufferSize:    32 * 1024, // 32KB
-		RetryAttempts: 3,
-		RetryDelay:    "1s",
-		LogLevel:      LogNormal,
+		Concurrency:    2,
+		BufferSize:     32 * 1024, // 32KB
+		RetryAttempts:  3,
+		RetryDelay:     "1s",
+		LogLevel:       LogNormal,
+		ChunkCacheSize: 256 * 1024 * 1024, // 256MB
 		ExcludePatterns: []string{
 			"*.tmp",
 			".DS_Store",
@@ -0,0 +1,34 @@
+// internal/core/backup/events.go
+package backup
+
+import (
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+)
+
+// eventBridge adapts worker.Pool's per-task Reporter callbacks into
+// backup.Event values sent on events, so a Service.Backup run's progress is
+// a single typed stream a ui.Reporter consumes, rather than the pool's
+// callbacks driving a renderer directly.
+type eventBridge struct {
+	events chan<- backup.Event
+}
+
+// TaskStarted implements worker.Reporter.
+func (b *eventBridge) TaskStarted(workerID int, task backup.BackupTask) {
+	b.events <- backup.Event{Type: backup.EventFileStarted, WorkerID: workerID, Path: task.Source}
+}
+
+// TaskFinished implements worker.Reporter.
+func (b *eventBridge) TaskFinished(workerID int, result backup.TaskResult) {
+	e := backup.Event{WorkerID: workerID, Path: result.Task.Source, Bytes: result.Bytes}
+	switch result.Status {
+	case "completed":
+		e.Type = backup.EventFileCompleted
+	case "skipped":
+		e.Type = backup.EventFileSkipped
+	default:
+		e.Type = backup.EventFileFailed
+		e.Err = result.Error
+	}
+	b.events <- e
+}
@@ -3,12 +3,15 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
+	"github.com/jack-sneddon/backup-butler/internal/core/taskerr"
+	"github.com/jack-sneddon/backup-butler/internal/core/worker"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/fs"
+	"github.com/jack-sneddon/backup-butler/internal/ui"
 )
 
 type Service struct {
@@ -38,15 +41,26 @@ func NewService(
 	}
 }
 
+// Backup runs a full backup and returns once every task has settled, or
+// ctx is cancelled and in-flight tasks have been given a chance to wind
+// down. This doesn't install its own signal handler: the CLI layer already
+// arms one (see commands.cli.Execute's signal.NotifyContext) and cancels
+// the same ctx passed in here, so a second handler would just race the
+// first one to stop the run.
 func (s *Service) Backup(ctx context.Context) error {
 	// Create backup tasks
-	tasks, totalFiles, err := s.taskMgr.CreateTasks(s.config)
+	tasks, totalFiles, err := s.taskMgr.CreateTasks(ctx, s.config)
 	if err != nil {
 		return fmt.Errorf("failed to create backup tasks: %w", err)
 	}
 
+	var totalBytes int64
+	for _, task := range tasks {
+		totalBytes += task.Size
+	}
+
 	if !s.config.Options.Quiet {
-		fmt.Printf("Starting backup of %d files...\n", totalFiles)
+		ui.Message("Starting backup of %d files...", totalFiles)
 	}
 
 	// Initialize metrics
@@ -55,8 +69,39 @@ func (s *Service) Backup(ctx context.Context) error {
 	}
 	s.metrics.StartTracking(ctx)
 
+	// events carries this run's typed progress stream - the same one
+	// ScanStarted/FileStarted/FileCompleted/.../VersionComplete events a
+	// ui.Reporter renders, whether that's a live terminal status block or a
+	// line-delimited JSON stream - instead of the engine calling a renderer
+	// directly. A reporter is attached unless the run is quiet without a
+	// JSON stream; in that case events stays nil.
+	var events chan backup.Event
+	if reporting, ok := s.workerPool.(interface {
+		SetReporter(r worker.Reporter)
+	}); ok && (!s.config.Options.Quiet || s.config.Options.JSON) {
+		events = make(chan backup.Event, 64)
+		reporter := ui.NewReporter(totalFiles, totalBytes, s.config.Options.JSON)
+		reporting.SetReporter(&eventBridge{events: events})
+		reporter.Start()
+
+		consumerDone := make(chan struct{})
+		go func() {
+			defer close(consumerDone)
+			for e := range events {
+				reporter.Handle(e)
+			}
+		}()
+		defer func() {
+			close(events)
+			<-consumerDone
+			reporter.Stop()
+		}()
+
+		events <- backup.Event{Type: backup.EventScanStarted, FilesTotal: totalFiles, BytesTotal: totalBytes}
+	}
+
 	// Start new backup version
-	s.versioner.StartNewVersion(s.config)
+	version := s.versioner.StartNewVersion(s.config)
 
 	// Process tasks
 	resultCh := s.workerPool.Execute(ctx, tasks)
@@ -65,10 +110,23 @@ func (s *Service) Backup(ctx context.Context) error {
 	for result := range resultCh {
 		// Update versioning info
 		if result.Status != "failed" {
-			metadata, err := s.storage.GetMetadata(result.Task.Source)
+			metadata, err := s.storage.GetMetadata(ctx, result.Task.Source)
 			if err != nil {
-				fmt.Printf("Error: Failed to get metadata for %s: %v\n", result.Task.Source, err)
+				ui.Error("Failed to get metadata for %s: %v", result.Task.Source, err)
 			} else {
+				// If the task manager backed this file up as content-defined
+				// chunks (see core/task/chunked.go), attach its chunk
+				// manifest so BackupVersion.Files records a dedup-friendly
+				// chunk list instead of only a whole-file checksum. Not a
+				// TaskManagerPort method - most implementations have no
+				// manifest to report.
+				if chunksProvider, ok := s.taskMgr.(interface {
+					ChunksFor(dst string) ([]backup.ChunkRef, error)
+				}); ok {
+					if chunks, err := chunksProvider.ChunksFor(result.Task.Destination); err == nil {
+						metadata.Chunks = chunks
+					}
+				}
 				s.versioner.AddFile(result.Task.Source, metadata)
 			}
 		}
@@ -81,40 +139,103 @@ func (s *Service) Backup(ctx context.Context) error {
 			s.metrics.IncrementSkipped(result.Bytes)
 		case "failed":
 			s.metrics.IncrementFailed()
+			if recorder, ok := s.metrics.(interface{ RecordError(*taskerr.TaskError) }); ok {
+				var terr *taskerr.TaskError
+				if errors.As(result.Error, &terr) {
+					recorder.RecordError(terr)
+				}
+			}
 			if !s.config.Options.Quiet {
-				fmt.Printf("Error: Failed to process %s: %v\n", result.Task.Source, result.Error)
+				ui.Error("Failed to process %s: %v", result.Task.Source, result.Error)
 			}
 		}
 	}
 
-	// Get final stats and complete version
+	// If the storage port has an attached checksum cache (see
+	// core/storage/cache), fold its cumulative hit/miss count into this
+	// run's metrics before reading final stats, and flush it to disk so
+	// the next run sees what this one learned. Neither is a StoragePort
+	// method - most implementations (mocks, tests) have no cache to report.
+	if cacheReporter, ok := s.storage.(interface{ CacheStats() (int, int) }); ok {
+		if statsSetter, ok := s.metrics.(interface{ SetCacheStats(hits, misses int) }); ok {
+			hits, misses := cacheReporter.CacheStats()
+			statsSetter.SetCacheStats(hits, misses)
+		}
+	}
+	if flusher, ok := s.storage.(interface{ FlushCache() error }); ok {
+		if err := flusher.FlushCache(); err != nil {
+			ui.Error("Failed to flush checksum cache: %v", err)
+		}
+	}
+
+	// If the storage port supports delta copy (see core/storage.CopyDelta),
+	// fold its cumulative bytes-reused total into this run's metrics the
+	// same way the checksum cache's hit/miss count is folded in above.
+	if reuseReporter, ok := s.storage.(interface{ BytesReused() int64 }); ok {
+		if statsSetter, ok := s.metrics.(interface{ SetBytesReused(int64) }); ok {
+			statsSetter.SetBytesReused(reuseReporter.BytesReused())
+		}
+	}
+
+	// Get final stats and complete version. A cancelled context means the
+	// worker pool stopped feeding new tasks and the copies in flight were
+	// aborted, so the version is saved as a partial record a later run can
+	// build on instead of a completed one.
 	stats := s.metrics.GetStats()
-	if err := s.versioner.CompleteVersion(stats); err != nil {
-		fmt.Printf("Error: Failed to save backup version: %v\n", err)
+	if ctx.Err() != nil {
+		if err := s.versioner.CancelVersion(stats); err != nil {
+			ui.Error("Failed to save cancelled backup version: %v", err)
+		}
+	} else if err := s.versioner.CompleteVersion(stats); err != nil {
+		ui.Error("Failed to save backup version: %v", err)
+	} else if compacter, ok := s.taskMgr.(interface{ CompactCheckpoint() error }); ok {
+		// Only compact the checkpoint journal once the version it backs
+		// has actually been saved as complete - a cancelled run leaves it
+		// in place so a later --resume (the default) can pick up from it.
+		if err := compacter.CompactCheckpoint(); err != nil {
+			ui.Error("Failed to compact checkpoint journal: %v", err)
+		}
+	}
+
+	if events != nil {
+		events <- backup.Event{Type: backup.EventVersionComplete, Stats: stats}
 	}
 
 	// Wait for any final updates
 	time.Sleep(200 * time.Millisecond)
 
+	// If any tasks failed, save the structured per-code/per-directory error
+	// report alongside the version's own files, and print its human-table
+	// counterpart before the plain final summary. Neither is a MetricsPort
+	// method - reached the same way SetCacheStats/SetBytesReused are -
+	// since a clean run (or a fake MetricsPort in a test) has nothing to
+	// report.
+	if reporter, ok := s.metrics.(interface {
+		WriteErrorReport(baseDir, runID string) error
+	}); ok {
+		if err := reporter.WriteErrorReport(s.config.TargetDirectory, version.ID); err != nil {
+			ui.Error("Failed to write error report: %v", err)
+		}
+	}
+	if displayer, ok := s.metrics.(interface{ DisplayErrorSummary(runID string) }); ok {
+		displayer.DisplayErrorSummary(version.ID)
+	}
+
 	// Display final summary
 	s.metrics.DisplayFinalSummary()
 
 	return nil
 }
 
+// DryRun reports what a backup would do without touching disk. It used to
+// redirect config.TargetDirectory to a fixed os.TempDir() path so the real
+// storage port's existence checks came back empty - which left stale state
+// on disk between runs and still went through the filesystem. It now checks
+// each task's destination against a fresh fs.Mem instead, which starts
+// empty every call: same "every file would be copied" result, no disk
+// touched, and no leftover temp directory to worry about.
 func (s *Service) DryRun(ctx context.Context) error {
-	// Store original config target and create tasks without creating directories
-	originalTarget := s.config.TargetDirectory
-	tempTarget := filepath.Join(os.TempDir(), "backup-butler-dryrun")
-	s.config.TargetDirectory = tempTarget
-
-	// Restore original target when done
-	defer func() {
-		s.config.TargetDirectory = originalTarget
-	}()
-
-	// Create backup tasks
-	tasks, totalFiles, err := s.taskMgr.CreateTasks(s.config)
+	tasks, totalFiles, err := s.taskMgr.CreateTasks(ctx, s.config)
 	if err != nil {
 		return fmt.Errorf("failed to create backup tasks: %w", err)
 	}
@@ -122,17 +243,14 @@ func (s *Service) DryRun(ctx context.Context) error {
 	fmt.Printf("Starting dry run analysis of %d files...\n", totalFiles)
 
 	stats := &DryRunStats{TotalFiles: totalFiles}
+	dryTarget := fs.NewMem()
 
 	// Process each task sequentially - pure analysis, no directory creation
 	for _, task := range tasks {
-		exists, _ := s.storage.Exists(task.Destination)
-		if exists {
-			shouldSkip, _ := s.taskMgr.ShouldSkipFile(task)
-			if shouldSkip {
-				stats.UpdateForFile(task, false)
-				stats.LogFileStatus(task, false, s.config.Options.Quiet)
-				continue
-			}
+		if _, err := dryTarget.Stat(task.Destination); err == nil {
+			stats.UpdateForFile(task, false)
+			stats.LogFileStatus(task, false, s.config.Options.Quiet)
+			continue
 		}
 
 		stats.UpdateForFile(task, true)
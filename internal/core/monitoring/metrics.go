@@ -3,37 +3,107 @@ package monitoring
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/jack-sneddon/backup-butler/internal/core/taskerr"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/progress"
 )
 
 const (
-	progressInterval = 100 * time.Millisecond
 	progressBarWidth = 30
 	megabyte         = 1024 * 1024
+	// topFailingDirs bounds how many directories DisplayErrorSummary and
+	// WriteErrorReport list, sorted by failure count - a run against a
+	// source with one bad mount point shouldn't print one line per file.
+	topFailingDirs = 5
 )
 
+// ErrorRecord captures one failed task for this run's end-of-run error
+// report - RecordError appends one each time a task fails, regardless of
+// whether it was eventually retried into success by a later attempt on a
+// different task.
+type ErrorRecord struct {
+	Code        taskerr.Code     `json:"code"`
+	Category    taskerr.Category `json:"category"`
+	Path        string           `json:"path"`
+	BytesCopied int64            `json:"bytes_copied"`
+	RetryCount  int              `json:"retry_count"`
+	Message     string           `json:"message"`
+	Occurred    time.Time        `json:"occurred"`
+}
+
+// CodeSummary aggregates this run's ErrorRecords sharing a Code.
+type CodeSummary struct {
+	Code      taskerr.Code     `json:"code"`
+	Category  taskerr.Category `json:"category"`
+	Count     int              `json:"count"`
+	FirstSeen time.Time        `json:"first_seen"`
+	LastSeen  time.Time        `json:"last_seen"`
+}
+
+// DirSummary counts failures under one directory, for the top-N list
+// WriteErrorReport and DisplayErrorSummary surface.
+type DirSummary struct {
+	Dir   string `json:"dir"`
+	Count int    `json:"count"`
+}
+
+// ErrorSummary is the JSON document WriteErrorReport saves to
+// .backup-butler/errors-<runID>.json, and what DisplayErrorSummary renders
+// as a human table.
+type ErrorSummary struct {
+	RunID          string        `json:"run_id"`
+	TotalErrors    int           `json:"total_errors"`
+	ByCode         []CodeSummary `json:"by_code"`
+	TopFailingDirs []DirSummary  `json:"top_failing_dirs"`
+	Errors         []ErrorRecord `json:"errors"`
+}
+
 // Metrics handles tracking and reporting of backup progress
 type Metrics struct {
 	mu            sync.RWMutex
 	totalFiles    int
+	totalBytes    int64
 	filesComplete int
 	bytesComplete int64
 	filesSkipped  int
 	filesFailed   int
+	cacheHits     int
+	cacheMisses   int
+	bytesReused   int64
+	errorRecords  []ErrorRecord
 	startTime     time.Time
 	quiet         bool
+	jsonMode      bool
+	printer       progress.ProgressPrinter
+	reporter      *progress.Reporter
 	cancelFunc    context.CancelFunc
 }
 
-func NewMetrics(quiet bool) *Metrics {
+// NewMetrics creates a Metrics tracker. When jsonMode is true, progress and
+// the final summary are reported as line-delimited JSON events instead of
+// the redrawn-in-place text bar, so `backup-butler backup --json` produces
+// stable, machine-consumable output.
+func NewMetrics(quiet, jsonMode bool) *Metrics {
+	var printer progress.ProgressPrinter
+	if jsonMode {
+		printer = progress.NewJSONPrinter(os.Stdout, os.Stderr)
+	} else {
+		printer = progress.NewTextPrinter(os.Stdout, os.Stderr, progressBarWidth)
+	}
+
 	return &Metrics{
 		startTime: time.Now(),
 		quiet:     quiet,
+		jsonMode:  jsonMode,
+		printer:   printer,
 	}
 }
 
@@ -43,16 +113,27 @@ func (m *Metrics) SetTotalFiles(total int) {
 	m.totalFiles = total
 }
 
+// AddToTotal grows the run's file/byte totals by amounts discovered after
+// tracking started, so a caller fed by a streaming scan (which emits files
+// as it finds them rather than counting up front) can keep the progress
+// bar's denominator accurate as discovery proceeds.
+func (m *Metrics) AddToTotal(files int, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.totalFiles += files
+	m.totalBytes += bytes
+}
+
+// StartTracking resets the run's counters and arms StopTracking's cancel
+// func. It no longer spawns its own ticking display: the ui.Reporter
+// consuming Service.Backup's event stream now owns the live status block,
+// and running both here would tear it.
 func (m *Metrics) StartTracking(ctx context.Context) {
 	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.resetMetrics()
-	trackingCtx, cancel := context.WithCancel(ctx)
+	_, cancel := context.WithCancel(ctx)
 	m.cancelFunc = cancel
-	m.mu.Unlock()
-
-	if !m.quiet {
-		go m.trackProgress(trackingCtx)
-	}
 }
 
 func (m *Metrics) StopTracking() {
@@ -66,21 +147,19 @@ func (m *Metrics) resetMetrics() {
 	m.filesSkipped = 0
 	m.filesFailed = 0
 	m.bytesComplete = 0
+	m.errorRecords = nil
 	m.startTime = time.Now()
 }
 
-func (m *Metrics) trackProgress(ctx context.Context) {
-	ticker := time.NewTicker(progressInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			m.DisplayProgress()
-		}
-	}
+// SetReporter attaches a shared progress.Reporter that Increment* pushes
+// per-file updates into as the backup phase runs, the same reporter
+// scan.Scanner can push its own StartFile/CompleteFile updates into during
+// the scan phase - so one reporter drives the display across both phases
+// instead of Metrics redrawing its own.
+func (m *Metrics) SetReporter(r *progress.Reporter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reporter = r
 }
 
 func (m *Metrics) IncrementCompleted(bytes int64) {
@@ -88,6 +167,9 @@ func (m *Metrics) IncrementCompleted(bytes int64) {
 	defer m.mu.Unlock()
 	m.filesComplete++
 	m.bytesComplete += bytes
+	if m.reporter != nil {
+		m.reporter.CompleteFile("completed", bytes)
+	}
 }
 
 func (m *Metrics) IncrementSkipped(bytes int64) {
@@ -95,12 +177,169 @@ func (m *Metrics) IncrementSkipped(bytes int64) {
 	defer m.mu.Unlock()
 	m.filesSkipped++
 	m.bytesComplete += bytes
+	if m.reporter != nil {
+		m.reporter.CompleteFile("skipped", bytes)
+	}
 }
 
 func (m *Metrics) IncrementFailed() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.filesFailed++
+	if m.reporter != nil {
+		m.reporter.CompleteFile("failed", 0)
+	}
+}
+
+// SetCacheStats records a storage.Manager checksum cache's cumulative
+// hit/miss counts for this run, so GetStats' BackupStats carries them into
+// the version summary. Service.Backup calls this through an interface
+// check, the same way it calls SetTotalFiles, since
+// MetricsPort itself has no cache-specific method and most StoragePort
+// implementations have no cache to report.
+func (m *Metrics) SetCacheStats(hits, misses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheHits = hits
+	m.cacheMisses = misses
+}
+
+// SetBytesReused records storage.Manager.CopyDelta's cumulative bytes
+// reused from an existing destination for this run. Service.Backup calls
+// this through the same kind of interface check as SetCacheStats, since
+// most StoragePort implementations have no delta copy to report.
+func (m *Metrics) SetBytesReused(bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytesReused = bytes
+}
+
+// RecordError appends terr to this run's error records, for
+// DisplayErrorSummary and WriteErrorReport to aggregate at end-of-run.
+// Called once per failed task - IncrementFailed already bumped the plain
+// failure count, this is what lets the summary say why those files failed
+// instead of just how many.
+func (m *Metrics) RecordError(terr *taskerr.TaskError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errorRecords = append(m.errorRecords, ErrorRecord{
+		Code:        terr.Code,
+		Category:    terr.Category,
+		Path:        terr.Path,
+		BytesCopied: terr.BytesCopied,
+		RetryCount:  terr.RetryCount,
+		Message:     terr.Error(),
+		Occurred:    time.Now(),
+	})
+}
+
+// buildErrorSummary aggregates the run's recorded errors by Code and by
+// containing directory. Callers must hold at least m.mu.RLock.
+func (m *Metrics) buildErrorSummary(runID string) ErrorSummary {
+	summary := ErrorSummary{
+		RunID:       runID,
+		TotalErrors: len(m.errorRecords),
+		Errors:      append([]ErrorRecord(nil), m.errorRecords...),
+	}
+
+	byCode := make(map[taskerr.Code]*CodeSummary)
+	var codeOrder []taskerr.Code
+	byDir := make(map[string]int)
+
+	for _, rec := range m.errorRecords {
+		cs, ok := byCode[rec.Code]
+		if !ok {
+			cs = &CodeSummary{Code: rec.Code, Category: rec.Category, FirstSeen: rec.Occurred, LastSeen: rec.Occurred}
+			byCode[rec.Code] = cs
+			codeOrder = append(codeOrder, rec.Code)
+		}
+		cs.Count++
+		if rec.Occurred.Before(cs.FirstSeen) {
+			cs.FirstSeen = rec.Occurred
+		}
+		if rec.Occurred.After(cs.LastSeen) {
+			cs.LastSeen = rec.Occurred
+		}
+		byDir[filepath.Dir(rec.Path)]++
+	}
+
+	for _, code := range codeOrder {
+		summary.ByCode = append(summary.ByCode, *byCode[code])
+	}
+
+	for dir, count := range byDir {
+		summary.TopFailingDirs = append(summary.TopFailingDirs, DirSummary{Dir: dir, Count: count})
+	}
+	sort.Slice(summary.TopFailingDirs, func(i, j int) bool {
+		return summary.TopFailingDirs[i].Count > summary.TopFailingDirs[j].Count
+	})
+	if len(summary.TopFailingDirs) > topFailingDirs {
+		summary.TopFailingDirs = summary.TopFailingDirs[:topFailingDirs]
+	}
+
+	return summary
+}
+
+// DisplayErrorSummary prints a human table of this run's failures - counts
+// per code with first/last occurrence, then the directories that failed
+// most - after DisplayFinalSummary's own totals. A no-op when nothing
+// failed, or when quiet suppresses interactive output the same way
+// DisplayFinalSummary respects it.
+func (m *Metrics) DisplayErrorSummary(runID string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.errorRecords) == 0 || (m.quiet && !m.jsonMode) {
+		return
+	}
+
+	summary := m.buildErrorSummary(runID)
+
+	fmt.Println("\nFailures by code:")
+	for _, cs := range summary.ByCode {
+		fmt.Printf("  %-22s %-10s %5d   first %s   last %s\n",
+			cs.Code, cs.Category, cs.Count,
+			cs.FirstSeen.Format(time.RFC3339), cs.LastSeen.Format(time.RFC3339))
+	}
+
+	if len(summary.TopFailingDirs) > 0 {
+		fmt.Println("\nTop failing directories:")
+		for _, ds := range summary.TopFailingDirs {
+			fmt.Printf("  %-40s %5d\n", ds.Dir, ds.Count)
+		}
+	}
+}
+
+// WriteErrorReport saves this run's ErrorSummary as
+// <baseDir>/.backup-butler/errors-<runID>.json, the machine-readable
+// counterpart to DisplayErrorSummary's table, for a caller scripting
+// against run results instead of reading the console. A no-op, leaving no
+// file behind, when nothing failed.
+func (m *Metrics) WriteErrorReport(baseDir, runID string) error {
+	m.mu.RLock()
+	hasErrors := len(m.errorRecords) > 0
+	summary := m.buildErrorSummary(runID)
+	m.mu.RUnlock()
+
+	if !hasErrors {
+		return nil
+	}
+
+	dir := filepath.Join(baseDir, ".backup-butler")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create .backup-butler directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal error summary: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("errors-%s.json", runID))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write error report: %w", err)
+	}
+	return nil
 }
 
 func (m *Metrics) GetStats() backup.BackupStats {
@@ -112,63 +351,44 @@ func (m *Metrics) GetStats() backup.BackupStats {
 		FilesBackedUp:    m.filesComplete,
 		FilesSkipped:     m.filesSkipped,
 		FilesFailed:      m.filesFailed,
-		TotalBytes:       m.bytesComplete,
+		TotalBytes:       m.totalBytes,
 		BytesTransferred: m.bytesComplete,
+		CacheHits:        m.cacheHits,
+		CacheMisses:      m.cacheMisses,
+		BytesReused:      m.bytesReused,
 	}
 }
 
+// DisplayProgress reports the run's current progress through the
+// configured ProgressPrinter. Interactive (non-JSON) progress is suppressed
+// entirely when quiet is set; a JSON stream keeps emitting status events
+// even when quiet, since --quiet only means "no interactive bar", not
+// "no machine-readable output".
 func (m *Metrics) DisplayProgress() {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if m.totalFiles == 0 {
+	if m.totalFiles == 0 || (m.quiet && !m.jsonMode) {
 		return
 	}
 
 	total := m.filesComplete + m.filesSkipped
-	percentComplete := (float64(total) / float64(m.totalFiles)) * 100.0
-	if total == m.totalFiles {
-		percentComplete = 100.0
-	}
-
-	// Create progress bar
-	completed := int((percentComplete / 100.0) * float64(progressBarWidth))
-	completed = clamp(completed, 0, progressBarWidth)
-	bar := createProgressBar(completed)
-
-	// Calculate transfer speed
-	speed := m.calculateTransferSpeed()
-
-	// Display progress
-	fmt.Print("\x1b[s")     // Save cursor position
-	fmt.Print("\x1b[1000D") // Move cursor far left
-	fmt.Print("\x1b[K")     // Clear line
-	fmt.Printf("[%s] %5.1f%% | %3d copied, %3d skipped of %3d files | %6.2f MB | %6.2f MB/s",
-		bar,
-		percentComplete,
-		m.filesComplete,
-		m.filesSkipped,
-		m.totalFiles,
-		float64(m.bytesComplete)/megabyte,
-		speed)
-	fmt.Print("\x1b[u") // Restore cursor position
+	m.printer.ReportTotal(total, m.totalFiles, m.bytesComplete, m.totalBytes, m.calculateTransferSpeed()*megabyte)
 }
 
+// DisplayFinalSummary reports the run's final summary through the
+// configured ProgressPrinter. Unlike DisplayProgress, this always fires in
+// JSON mode even when quiet, so a scripted `--quiet --json` caller still
+// gets the summary event it needs to know the run finished.
 func (m *Metrics) DisplayFinalSummary() {
-	if m.quiet {
+	if m.quiet && !m.jsonMode {
 		return
 	}
 
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	duration := time.Since(m.startTime)
-	fmt.Printf("\n\nBackup completed in %v\n", duration)
-	fmt.Printf("Files processed: %d, Files skipped: %d, Failed: %d, Total size: %.2f MB\n",
-		m.filesComplete,
-		m.filesSkipped,
-		m.filesFailed,
-		float64(m.bytesComplete)/megabyte)
+	m.printer.Finish(m.filesComplete, m.filesSkipped, m.filesFailed, m.bytesComplete, time.Since(m.startTime))
 }
 
 func (m *Metrics) calculateTransferSpeed() float64 {
@@ -178,17 +398,3 @@ func (m *Metrics) calculateTransferSpeed() float64 {
 	}
 	return 0
 }
-
-func createProgressBar(completed int) string {
-	return strings.Repeat("█", completed) + strings.Repeat("░", progressBarWidth-completed)
-}
-
-func clamp(value, min, max int) int {
-	if value < min {
-		return min
-	}
-	if value > max {
-		return max
-	}
-	return value
-}
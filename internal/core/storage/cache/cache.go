@@ -0,0 +1,197 @@
+// Package cache is a persistent checksum/metadata cache keyed by a file's
+// path, size, mtime, and inode, so a re-run over a mostly unchanged media
+// library doesn't re-hash every file - only ones whose identity has
+// actually changed since the last recorded entry. Modeled on buildkit's
+// fscache: a disk-backed index consulted before doing expensive work, with
+// Prune/GC to keep it from growing unbounded.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// entry records what CalculateChecksum last saw for a path, and when it was
+// last consulted, so Prune/GC have a recency signal to evict by.
+type entry struct {
+	Size         int64     `json:"size"`
+	ModTime      time.Time `json:"mod_time"`
+	Inode        uint64    `json:"inode"`
+	Checksum     string    `json:"checksum"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+// Store is a JSON-file-backed checksum cache rooted at baseDir/.versions,
+// safe for concurrent readers the way version.Manager's own JSON stores
+// are - a single RWMutex guarding an in-memory map, flushed to disk on
+// demand rather than on every write.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	entries map[string]entry
+	hits    int
+	misses  int
+}
+
+// Open loads (or creates) the checksum cache rooted at baseDir/.versions,
+// alongside version.Manager's own version index and chunkstore.Store.
+func Open(baseDir string) (*Store, error) {
+	dir := filepath.Join(baseDir, ".versions")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		path:    filepath.Join(dir, "checksum_cache.json"),
+		entries: make(map[string]entry),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &s.entries)
+}
+
+// Flush writes the cache's current state to disk. Callers that call Put
+// many times in a run should flush once at the end rather than after every
+// entry.
+func (s *Store) Flush() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.save()
+}
+
+func (s *Store) save() error {
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Lookup returns the checksum cached for path if its size, modTime, and
+// inode all match the recorded entry, touching the entry's LastAccessed
+// and counting a hit; any mismatch - including no entry at all - counts a
+// miss and reports ok=false, so the caller knows to hash path itself.
+func (s *Store) Lookup(path string, size int64, modTime time.Time, inode uint64) (checksum string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, found := s.entries[path]
+	if !found || e.Size != size || !e.ModTime.Equal(modTime) || e.Inode != inode {
+		s.misses++
+		return "", false
+	}
+
+	e.LastAccessed = time.Now()
+	s.entries[path] = e
+	s.hits++
+	return e.Checksum, true
+}
+
+// Put records checksum for path under its current size/modTime/inode,
+// replacing whatever entry (if any) was there before.
+func (s *Store) Put(path string, size int64, modTime time.Time, inode uint64, checksum string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[path] = entry{
+		Size:         size,
+		ModTime:      modTime,
+		Inode:        inode,
+		Checksum:     checksum,
+		LastAccessed: time.Now(),
+	}
+}
+
+// Stats reports this Store's cumulative hit/miss count since it was
+// opened.
+func (s *Store) Stats() (hits, misses int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hits, s.misses
+}
+
+// Size reports how many entries the cache holds and their total serialized
+// size in bytes - the same size Prune measures itself against via
+// --keep-storage - without needing a live backup run in progress, so a
+// standalone `cache stats` command has something to report.
+func (s *Store) Size() (entries int, bytes int64, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(s.entries), int64(len(data)), nil
+}
+
+// Prune evicts the least-recently-accessed entries, oldest first, until
+// the cache's serialized size is at or under keepBytes, then flushes the
+// result to disk. It returns how many entries were removed.
+func (s *Store) Prune(keepBytes int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type byAccess struct {
+		path string
+		last time.Time
+	}
+	ordered := make([]byAccess, 0, len(s.entries))
+	for path, e := range s.entries {
+		ordered = append(ordered, byAccess{path, e.LastAccessed})
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].last.Before(ordered[j].last) })
+
+	removed := 0
+	for len(ordered) > 0 {
+		data, err := json.Marshal(s.entries)
+		if err != nil {
+			return removed, err
+		}
+		if int64(len(data)) <= keepBytes {
+			break
+		}
+
+		victim := ordered[0]
+		ordered = ordered[1:]
+		delete(s.entries, victim.path)
+		removed++
+	}
+
+	return removed, s.save()
+}
+
+// GC removes every entry not consulted within olderThan, then flushes the
+// result to disk. It returns how many entries were removed.
+func (s *Store) GC(olderThan time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+	for path, e := range s.entries {
+		if e.LastAccessed.Before(cutoff) {
+			delete(s.entries, path)
+			removed++
+		}
+	}
+
+	return removed, s.save()
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"io"
@@ -13,7 +14,10 @@ func NewChecksumCalculator() *ChecksumCalculator {
 	return &ChecksumCalculator{}
 }
 
-func (c *ChecksumCalculator) CalculateChecksum(filePath string) (string, error) {
+// CalculateChecksum hashes filePath in chunks, checking ctx between reads so
+// a cancelled run aborts hashing a large file instead of running it to
+// completion.
+func (c *ChecksumCalculator) CalculateChecksum(ctx context.Context, filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
@@ -21,9 +25,23 @@ func (c *ChecksumCalculator) CalculateChecksum(filePath string) (string, error)
 	defer file.Close()
 
 	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
-	}
+	buf := make([]byte, 32*1024)
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+		}
 
-	return hex.EncodeToString(hash.Sum(nil)), nil
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return hex.EncodeToString(hash.Sum(nil)), nil
+			}
+			return "", readErr
+		}
+	}
 }
@@ -67,3 +67,13 @@ func newCopyError(src, dst string, err error) *StorageError {
 		Err:   err,
 	}
 }
+
+func newRenameError(oldPath, newPath string, err error) *StorageError {
+	return &StorageError{
+		Code:  ErrAccessDenied,
+		Op:    "Rename",
+		Path:  oldPath,
+		Path2: newPath,
+		Err:   err,
+	}
+}
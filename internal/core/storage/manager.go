@@ -2,16 +2,24 @@
 package storage
 
 import (
-	"io"
+	"context"
 	"os"
 	"path/filepath"
+	"sync"
+	"syscall"
 
+	"github.com/jack-sneddon/backup-butler/internal/core/storage/cache"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"golang.org/x/text/unicode/norm"
 )
 
 type Manager struct {
 	checksumCalculator *ChecksumCalculator
 	bufferSize         int
+	cache              *cache.Store
+
+	bytesReusedMu    sync.Mutex
+	bytesReusedTotal int64
 }
 
 // NewManager creates a new storage manager
@@ -25,17 +33,84 @@ func NewManager(bufferSize int) *Manager {
 	}
 }
 
-// CalculateChecksum calculates the checksum of a file
-func (m *Manager) CalculateChecksum(filePath string) (string, error) {
-	checksum, err := m.checksumCalculator.CalculateChecksum(filePath)
+// SetCache attaches a persistent checksum cache that CalculateChecksum
+// consults before re-hashing a file, keyed on (path, size, mtime, inode) so
+// an edited or replaced file is never served a stale digest. A nil cache
+// (the default) disables caching entirely.
+func (m *Manager) SetCache(c *cache.Store) {
+	m.cache = c
+}
+
+// FlushCache writes the attached cache's current state to disk. A no-op if
+// no cache is attached.
+func (m *Manager) FlushCache() error {
+	if m.cache == nil {
+		return nil
+	}
+	return m.cache.Flush()
+}
+
+// CacheStats reports the attached cache's cumulative hit/miss count, or
+// zero values if no cache is attached.
+func (m *Manager) CacheStats() (hits, misses int) {
+	if m.cache == nil {
+		return 0, 0
+	}
+	return m.cache.Stats()
+}
+
+// CalculateChecksum calculates the checksum of a file, aborting early if ctx
+// is cancelled partway through hashing a large file. With a cache attached,
+// it first checks filePath's current size/mtime/inode against the cached
+// entry and only re-hashes on a miss.
+func (m *Manager) CalculateChecksum(ctx context.Context, filePath string) (string, error) {
+	if m.cache == nil {
+		return m.hashFile(ctx, filePath)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return m.hashFile(ctx, filePath)
+	}
+
+	inode := inodeOf(info)
+	if checksum, ok := m.cache.Lookup(filePath, info.Size(), info.ModTime(), inode); ok {
+		return checksum, nil
+	}
+
+	checksum, err := m.hashFile(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	m.cache.Put(filePath, info.Size(), info.ModTime(), inode, checksum)
+	return checksum, nil
+}
+
+func (m *Manager) hashFile(ctx context.Context, filePath string) (string, error) {
+	checksum, err := m.checksumCalculator.CalculateChecksum(ctx, filePath)
 	if err != nil {
 		return "", newStorageError(ErrChecksumMismatch, "CalculateChecksum", filePath, err)
 	}
 	return checksum, nil
 }
 
-// Copy copies a file with the specified buffer size
-func (m *Manager) Copy(src, dst string, bufferSize int) (int64, error) {
+// inodeOf extracts the platform inode number from a FileInfo's Sys(), or 0
+// if the underlying type isn't the Unix *syscall.Stat_t this assumes
+// elsewhere (e.g. os.Chmod in Copy already assumes a POSIX permission
+// model).
+func inodeOf(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino
+	}
+	return 0
+}
+
+// Copy copies a file with the specified buffer size, checking ctx between
+// reads so a cancelled run aborts an in-flight copy instead of letting it
+// run to completion. On any copy error, including cancellation, it removes
+// the partially written dst rather than leaving a truncated file behind for
+// a later run to mistake for a real one.
+func (m *Manager) Copy(ctx context.Context, src, dst string, bufferSize int) (int64, error) {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return 0, newStorageError(ErrAccessDenied, "OpenFile", src, err)
@@ -57,8 +132,10 @@ func (m *Manager) Copy(src, dst string, bufferSize int) (int64, error) {
 	}
 	buffer := make([]byte, bufferSize)
 
-	copied, err := io.CopyBuffer(dstFile, srcFile, buffer)
+	copied, err := m.copyWithContext(ctx, dstFile, srcFile, buffer)
 	if err != nil {
+		dstFile.Close()
+		os.Remove(dst)
 		return copied, newCopyError(src, dst, err)
 	}
 
@@ -74,8 +151,15 @@ func (m *Manager) Copy(src, dst string, bufferSize int) (int64, error) {
 	return copied, nil
 }
 
-// Exists checks if a file or directory exists
-func (m *Manager) Exists(path string) (bool, error) {
+// Exists checks if a file or directory exists. It takes a ctx, like the
+// rest of StoragePort, purely for cancellation consistency up the call
+// stack - os.Stat itself is a single syscall with nothing to abort partway
+// through.
+func (m *Manager) Exists(ctx context.Context, path string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	_, err := os.Stat(path)
 	if err == nil {
 		return true, nil
@@ -87,7 +171,7 @@ func (m *Manager) Exists(path string) (bool, error) {
 }
 
 // GetMetadata gets file metadata
-func (m *Manager) GetMetadata(path string) (backup.FileMetadata, error) {
+func (m *Manager) GetMetadata(ctx context.Context, path string) (backup.FileMetadata, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return backup.FileMetadata{}, newStorageError(ErrMetadataReadFailed, "GetMetadata", path, err)
@@ -100,7 +184,7 @@ func (m *Manager) GetMetadata(path string) (backup.FileMetadata, error) {
 	}
 
 	if !info.IsDir() {
-		checksum, err := m.CalculateChecksum(path)
+		checksum, err := m.CalculateChecksum(ctx, path)
 		if err != nil {
 			return metadata, err // Error already wrapped by CalculateChecksum
 		}
@@ -110,8 +194,14 @@ func (m *Manager) GetMetadata(path string) (backup.FileMetadata, error) {
 	return metadata, nil
 }
 
-// CreateDirectory creates a directory and any necessary parents
-func (m *Manager) CreateDirectory(path string) error {
+// CreateDirectory creates a directory and any necessary parents. Like
+// Exists, it takes a ctx only for cancellation consistency - os.MkdirAll
+// isn't itself interruptible.
+func (m *Manager) CreateDirectory(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return newStorageError(ErrDirectoryCreationFailed, "CreateDirectory", path, err)
 	}
@@ -129,3 +219,17 @@ func (m *Manager) IsDirectory(path string) (bool, error) {
 	}
 	return info.IsDir(), nil
 }
+
+// Rename moves oldPath to newPath in place, without a content copy.
+func (m *Manager) Rename(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return newRenameError(oldPath, newPath, err)
+	}
+	return nil
+}
+
+// SameLogicalPath reports whether a and b are equal once both are folded to
+// NFC, even if they differ byte-wise.
+func (m *Manager) SameLogicalPath(a, b string) bool {
+	return norm.NFC.String(a) == norm.NFC.String(b)
+}
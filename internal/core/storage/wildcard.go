@@ -0,0 +1,159 @@
+// internal/core/storage/wildcard.go
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+)
+
+// ChecksumWildcard walks root and computes a single stable digest over every
+// file whose path (relative to root, slash-separated) matches includeGlobs
+// and none of excludeGlobs - an empty includeGlobs matches everything. The
+// digest folds in each matched file's path, size, mode, and content
+// checksum in sorted-path order, so it comes out identical regardless of
+// filesystem walk order, and changes if any matched file's content,
+// size, or permissions do. Neither DryRun nor the verify command calls it.
+func (m *Manager) ChecksumWildcard(ctx context.Context, root string, includeGlobs, excludeGlobs []string) (string, []backup.FileMetadata, error) {
+	includes, err := compileGlobs(includeGlobs)
+	if err != nil {
+		return "", nil, newStorageError(ErrInvalidOperation, "ChecksumWildcard", root, err)
+	}
+	excludes, err := compileGlobs(excludeGlobs)
+	if err != nil {
+		return "", nil, newStorageError(ErrInvalidOperation, "ChecksumWildcard", root, err)
+	}
+
+	type matchedFile struct {
+		meta backup.FileMetadata
+		mode os.FileMode
+	}
+	var matched []matchedFile
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("failed to determine relative path for %s: %w", path, err)
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if len(includes) > 0 && !anyMatch(includes, relPath) {
+			return nil
+		}
+		if anyMatch(excludes, relPath) {
+			return nil
+		}
+
+		checksum, err := m.CalculateChecksum(ctx, path)
+		if err != nil {
+			return err
+		}
+
+		matched = append(matched, matchedFile{
+			meta: backup.FileMetadata{
+				Path:     relPath,
+				Size:     info.Size(),
+				ModTime:  info.ModTime(),
+				Checksum: checksum,
+			},
+			mode: info.Mode(),
+		})
+		return nil
+	})
+	if err != nil {
+		return "", nil, newStorageError(ErrAccessDenied, "ChecksumWildcard", root, err)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].meta.Path < matched[j].meta.Path
+	})
+
+	digest := sha256.New()
+	files := make([]backup.FileMetadata, 0, len(matched))
+	for _, file := range matched {
+		fmt.Fprintf(digest, "%s\x00%d\x00%s\x00%s\n", file.meta.Path, file.meta.Size, file.mode, file.meta.Checksum)
+		files = append(files, file.meta)
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), files, nil
+}
+
+// globPattern is a precompiled glob supporting `**` (match any number of
+// path segments) in addition to the single-segment `*`/`?` that
+// filepath.Match already understands, so callers can write patterns like
+// `**/*.jpg`.
+type globPattern struct {
+	re *regexp.Regexp
+}
+
+func compileGlobs(patterns []string) ([]globPattern, error) {
+	compiled := make([]globPattern, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(globToRegexp(p))
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", p, err)
+		}
+		compiled = append(compiled, globPattern{re: re})
+	}
+	return compiled, nil
+}
+
+func anyMatch(globs []globPattern, relPath string) bool {
+	for _, g := range globs {
+		if g.re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp translates a shell-style glob into an anchored regexp: `**`
+// becomes `.*`, a lone `*` becomes `[^/]*`, `?` becomes `[^/]`, and
+// everything else is quoted literally.
+func globToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '.', '+', '(', ')', '|', '^', '$', '{', '}':
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			sb.WriteRune(runes[i])
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+// internal/core/task/checkpoint.go
+package task
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointRecord is appended to the checkpoint journal once a task's copy
+// has actually landed on disk, so a later run can tell a file was finished
+// without re-stating or re-hashing it.
+type CheckpointRecord struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	ModTime  int64  `json:"mod_time"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// Checkpoint is an append-only journal of completed tasks, rooted at
+// targetDir/.backup-butler/checkpoint.jsonl. A run that stops partway
+// through - killed, crashed, or cancelled - leaves the journal in place;
+// the next run's Manager.ShouldSkipFile consults it before falling back to
+// its usual existence/size/checksum checks, so files this run already
+// finished aren't re-copied. Compact removes the journal once a run
+// completes cleanly, since BackupVersion's own manifest (see
+// VersionManagerPort.AddFile) is the durable record from that point on.
+type Checkpoint struct {
+	path string
+
+	mu   sync.Mutex
+	f    *os.File
+	done map[string]bool
+}
+
+// checkpointDir is the subdirectory of a backup target the journal lives
+// under, alongside whatever else the target-side tooling keeps there.
+const checkpointDir = ".backup-butler"
+
+// OpenCheckpoint loads the checkpoint journal rooted at targetDir,
+// creating an empty one if none exists. When restart is true, any journal
+// from a previous run is discarded first - ShouldSkipFile then falls back
+// entirely on the destination's own state, the same as a first-ever run.
+func OpenCheckpoint(targetDir string, restart bool) (*Checkpoint, error) {
+	dir := filepath.Join(targetDir, checkpointDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "checkpoint.jsonl")
+
+	if restart {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	c := &Checkpoint{path: path, done: make(map[string]bool)}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	c.f = f
+
+	return c, nil
+}
+
+func (c *Checkpoint) load() error {
+	f, err := os.Open(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	// A journal entry is small, but guard against an unbounded line the
+	// default 64KB bufio.Scanner buffer would choke on.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rec CheckpointRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// A truncated final line from a run killed mid-write is
+			// expected, not corruption worth failing startup over.
+			continue
+		}
+		c.done[rec.Path] = true
+	}
+	return scanner.Err()
+}
+
+// Completed reports whether path has an entry in the journal already.
+func (c *Checkpoint) Completed(path string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[path]
+}
+
+// Append records rec and fsyncs the journal file, so a record a caller has
+// been told succeeded really has survived a crash immediately after.
+func (c *Checkpoint) Append(rec CheckpointRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := c.f.Sync(); err != nil {
+		return err
+	}
+	c.done[rec.Path] = true
+	return nil
+}
+
+// Compact closes and removes the journal, folding its record-keeping
+// responsibility back onto the version manifest now that a run has
+// completed cleanly and every entry it held is reflected there.
+func (c *Checkpoint) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.f.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// internal/core/task/chunked.go
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/storage/chunker"
+	"github.com/jack-sneddon/backup-butler/internal/version/chunkstore"
+)
+
+// manifestSuffix names the sidecar file ExecuteTask writes alongside a
+// chunked destination, recording the chunk list it was cut from so
+// ShouldSkipFile can diff a later run chunk-by-chunk instead of whole-file.
+// ShouldSkipFile reads the chunk list from this sidecar rather than from
+// VersionManagerPort; ChunksFor reads the same sidecar so Service.Backup
+// can also attach the list to the FileMetadata it records.
+const manifestSuffix = ".chunks.json"
+
+type chunkManifest struct {
+	Refs []chunkstore.ChunkRef `json:"refs"`
+}
+
+// chunkerForConfig returns a Chunker sized from PackSizeBytes, scaling
+// min/max the same way version/chunkstore derives its bounds from
+// AverageSize (half for min, 8x for max).
+func (m *Manager) chunkerForConfig() *chunker.Chunker {
+	avg := m.config.PackSizeBytes
+	if avg <= 0 {
+		avg = chunker.DefaultAverageSize
+	}
+	return chunker.NewWithSizes(avg/2, avg, avg*8)
+}
+
+// splitFile reads path once and returns both its content and the
+// content-defined chunk list cut from it, so executeChunkedCopy (which
+// needs both) doesn't read the file twice.
+func (m *Manager) splitFile(path string) ([]byte, []chunkstore.ChunkRef, error) {
+	f, err := m.fs.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chunks := m.chunkerForConfig().SplitBytes(data)
+	refs := make([]chunkstore.ChunkRef, len(chunks))
+	for i, c := range chunks {
+		refs[i] = chunkstore.ChunkRef{Hash: c.Digest, Offset: c.Offset, Length: c.Size}
+	}
+	return data, refs, nil
+}
+
+func manifestPath(dst string) string {
+	return dst + manifestSuffix
+}
+
+// readManifest returns nil (not an error) when dst has no manifest yet -
+// the first backup of a file, or one that predates ChunkedCopy being turned
+// on.
+func readManifest(dst string) (*chunkManifest, error) {
+	data, err := os.ReadFile(manifestPath(dst))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var man chunkManifest
+	if err := json.Unmarshal(data, &man); err != nil {
+		return nil, err
+	}
+	return &man, nil
+}
+
+func writeManifest(dst string, refs []chunkstore.ChunkRef) error {
+	data, err := json.Marshal(chunkManifest{Refs: refs})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath(dst), data, 0644)
+}
+
+func sameChunks(a, b []chunkstore.ChunkRef) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// executeChunkedCopy backs up task.Source as content-defined chunks: it
+// splits the file, stores any chunk not already present in a
+// content-addressed pack directory under the target (reusing
+// version/chunkstore's refcounted store, the same one version.Manager uses
+// for cross-version dedup), writes the destination from the same in-memory
+// read rather than copying the file twice, and records the chunk list
+// alongside it for shouldSkipChunked to diff on the next run.
+func (m *Manager) executeChunkedCopy(task backup.BackupTask) error {
+	data, refs, err := m.splitFile(task.Source)
+	if err != nil {
+		return fmt.Errorf("failed to split source file: %w", err)
+	}
+
+	store, err := chunkstore.Open(m.config.TargetDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to open chunk store: %w", err)
+	}
+	if _, err := store.Put(data); err != nil {
+		return fmt.Errorf("failed to store chunks: %w", err)
+	}
+
+	if err := os.WriteFile(task.Destination, data, 0644); err != nil {
+		return fmt.Errorf("failed to write destination file: %w", err)
+	}
+
+	if err := writeManifest(task.Destination, refs); err != nil {
+		return fmt.Errorf("failed to write chunk manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ChunksFor reads back the chunk manifest ExecuteTask wrote alongside dst
+// (if any) and translates it to backup.ChunkRef, so Service.Backup can
+// attach it to the FileMetadata it hands to VersionManagerPort.AddFile.
+// Service.Backup looks this up through an optional interface check, the
+// same way it does CacheStats/BytesReused, since TaskManagerPort itself has
+// no chunk-specific method and most TaskManagerPort implementations have no
+// manifest to report.
+func (m *Manager) ChunksFor(dst string) ([]backup.ChunkRef, error) {
+	manifest, err := readManifest(dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chunk manifest: %w", err)
+	}
+	if manifest == nil {
+		return nil, nil
+	}
+
+	refs := make([]backup.ChunkRef, len(manifest.Refs))
+	for i, r := range manifest.Refs {
+		refs[i] = backup.ChunkRef{Hash: r.Hash, Offset: r.Offset, Length: r.Length}
+	}
+	return refs, nil
+}
+
+// shouldSkipChunked compares task.Source's current chunk list against the
+// manifest ExecuteTask wrote for task.Destination on the prior run. This
+// catches a mid-file edit or append that leaves the file's overall size
+// unchanged - something the whole-file size/checksum compare in
+// ShouldSkipFile can't tell apart from an untouched file.
+func (m *Manager) shouldSkipChunked(task backup.BackupTask) (bool, error) {
+	manifest, err := readManifest(task.Destination)
+	if err != nil {
+		return false, fmt.Errorf("failed to read chunk manifest: %w", err)
+	}
+	if manifest == nil {
+		return false, nil
+	}
+
+	_, refs, err := m.splitFile(task.Source)
+	if err != nil {
+		return false, fmt.Errorf("failed to split source file: %w", err)
+	}
+
+	return sameChunks(refs, manifest.Refs), nil
+}
@@ -2,25 +2,32 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/jack-sneddon/backup-butler/internal/core/taskerr"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/fs"
+	"golang.org/x/text/unicode/norm"
 )
 
 const DefaultBufferSize = 32 * 1024
 
 type Manager struct {
-	storage backup.StoragePort
-	metrics backup.MetricsPort
-	config  *backup.BackupConfig
+	storage    backup.StoragePort
+	metrics    backup.MetricsPort
+	config     *backup.BackupConfig
+	fs         fs.Filesystem
+	checkpoint *Checkpoint
 }
 
 func NewManager(storage backup.StoragePort, metrics backup.MetricsPort) *Manager {
 	return &Manager{
 		storage: storage,
 		metrics: metrics,
+		fs:      fs.OS(),
 	}
 }
 
@@ -28,7 +35,34 @@ func (m *Manager) SetConfig(config *backup.BackupConfig) {
 	m.config = config
 }
 
-func (m *Manager) CreateTasks(config *backup.BackupConfig) ([]backup.BackupTask, int, error) {
+// SetFilesystem swaps the Filesystem scanFolder walks, so a caller can point
+// it at an in-memory fake instead of the real disk.
+func (m *Manager) SetFilesystem(filesystem fs.Filesystem) {
+	m.fs = filesystem
+}
+
+// SetCheckpoint attaches a checkpoint journal: ExecuteTask appends a
+// record to it on every successful copy, and ShouldSkipFile consults it
+// before falling back to its usual destination checks, so a run resumed
+// after an interruption doesn't re-stat or re-hash files the previous run
+// already finished. Not a TaskManagerPort method - app.Factory wires this
+// in directly, the same way core/backup.Service reaches optional storage
+// capabilities through an interface check rather than widening a port.
+func (m *Manager) SetCheckpoint(c *Checkpoint) {
+	m.checkpoint = c
+}
+
+// CompactCheckpoint folds the attached checkpoint journal back into the
+// version manifest by removing it, once core/backup.Service has completed
+// the run's version cleanly. A no-op if no checkpoint is attached.
+func (m *Manager) CompactCheckpoint() error {
+	if m.checkpoint == nil {
+		return nil
+	}
+	return m.checkpoint.Compact()
+}
+
+func (m *Manager) CreateTasks(ctx context.Context, config *backup.BackupConfig) ([]backup.BackupTask, int, error) {
 	m.SetConfig(config)
 	var tasks []backup.BackupTask
 	totalFiles := 0
@@ -37,7 +71,7 @@ func (m *Manager) CreateTasks(config *backup.BackupConfig) ([]backup.BackupTask,
 		srcPath := filepath.Join(config.SourceDirectory, folder)
 		dstPath := filepath.Join(config.TargetDirectory, folder)
 
-		err := m.scanFolder(srcPath, dstPath, config.ExcludePatterns, &tasks, &totalFiles)
+		err := m.scanFolder(ctx, srcPath, dstPath, config.ExcludePatterns, &tasks, &totalFiles)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan folder %s: %w", folder, err)
 		}
@@ -46,12 +80,16 @@ func (m *Manager) CreateTasks(config *backup.BackupConfig) ([]backup.BackupTask,
 	return tasks, totalFiles, nil
 }
 
-func (m *Manager) scanFolder(srcPath, dstPath string, excludePatterns []string, tasks *[]backup.BackupTask, totalFiles *int) error {
-	return filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+func (m *Manager) scanFolder(ctx context.Context, srcPath, dstPath string, excludePatterns []string, tasks *[]backup.BackupTask, totalFiles *int) error {
+	return m.fs.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if info.IsDir() {
 			return nil
 		}
@@ -65,6 +103,10 @@ func (m *Manager) scanFolder(srcPath, dstPath string, excludePatterns []string,
 			return fmt.Errorf("failed to determine relative path: %w", err)
 		}
 
+		if m.config.NormalizeUnicode {
+			relPath = norm.NFC.String(relPath)
+		}
+
 		destPath := filepath.Join(dstPath, relPath)
 		*tasks = append(*tasks, backup.BackupTask{
 			Source:      path,
@@ -87,22 +129,38 @@ func (m *Manager) isExcluded(filename string, patterns []string) bool {
 	return false
 }
 
-func (m *Manager) ShouldSkipFile(task backup.BackupTask) (bool, error) {
-	exists, err := m.storage.Exists(task.Destination)
+func (m *Manager) ShouldSkipFile(ctx context.Context, task backup.BackupTask) (bool, error) {
+	if m.checkpoint != nil && m.checkpoint.Completed(task.Source) {
+		return true, nil
+	}
+
+	exists, err := m.storage.Exists(ctx, task.Destination)
 	if err != nil {
 		return false, fmt.Errorf("failed to check destination existence: %w", err)
 	}
 
+	if !exists && m.config.NormalizeUnicode {
+		reconciled, err := m.reconcileNormalizedDestination(ctx, task.Destination)
+		if err != nil {
+			return false, fmt.Errorf("failed to reconcile Unicode-normalized destination: %w", err)
+		}
+		exists = reconciled
+	}
+
 	if !exists {
 		return false, nil
 	}
 
-	srcMeta, err := m.storage.GetMetadata(task.Source)
+	if m.config.ChunkedCopy {
+		return m.shouldSkipChunked(task)
+	}
+
+	srcMeta, err := m.storage.GetMetadata(ctx, task.Source)
 	if err != nil {
 		return false, fmt.Errorf("failed to get source metadata: %w", err)
 	}
 
-	dstMeta, err := m.storage.GetMetadata(task.Destination)
+	dstMeta, err := m.storage.GetMetadata(ctx, task.Destination)
 	if err != nil {
 		return false, fmt.Errorf("failed to get destination metadata: %w", err)
 	}
@@ -112,19 +170,46 @@ func (m *Manager) ShouldSkipFile(task backup.BackupTask) (bool, error) {
 	}
 
 	if m.config.DeepDuplicateCheck {
-		return m.compareChecksums(task)
+		return m.compareChecksums(ctx, task)
 	}
 
 	return true, nil
 }
 
-func (m *Manager) compareChecksums(task backup.BackupTask) (bool, error) {
-	srcChecksum, err := m.storage.CalculateChecksum(task.Source)
+// reconcileNormalizedDestination looks for a sibling of dst under its
+// NFD-decomposed name - the encoding filepath.Walk returns for filenames on
+// macOS APFS even when config lists the NFC form - and, following rclone's
+// macOS-rename fix, renames it onto dst's canonical NFC path rather than
+// leaving it in place to be treated as a missing file and re-copied. Reports
+// whether it found and renamed such a sibling.
+func (m *Manager) reconcileNormalizedDestination(ctx context.Context, dst string) (bool, error) {
+	nfd := filepath.Join(filepath.Dir(dst), norm.NFD.String(filepath.Base(dst)))
+	if nfd == dst {
+		return false, nil
+	}
+
+	exists, err := m.storage.Exists(ctx, nfd)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for a Unicode-equivalent destination: %w", err)
+	}
+	if !exists || !m.storage.SameLogicalPath(nfd, dst) {
+		return false, nil
+	}
+
+	if err := m.storage.Rename(nfd, dst); err != nil {
+		return false, fmt.Errorf("failed to rename %s to its canonical form: %w", nfd, err)
+	}
+
+	return true, nil
+}
+
+func (m *Manager) compareChecksums(ctx context.Context, task backup.BackupTask) (bool, error) {
+	srcChecksum, err := m.storage.CalculateChecksum(ctx, task.Source)
 	if err != nil {
 		return false, fmt.Errorf("failed to calculate source checksum: %w", err)
 	}
 
-	dstChecksum, err := m.storage.CalculateChecksum(task.Destination)
+	dstChecksum, err := m.storage.CalculateChecksum(ctx, task.Destination)
 	if err != nil {
 		return false, fmt.Errorf("failed to calculate destination checksum: %w", err)
 	}
@@ -132,28 +217,103 @@ func (m *Manager) compareChecksums(task backup.BackupTask) (bool, error) {
 	return srcChecksum == dstChecksum, nil
 }
 
-func (m *Manager) ExecuteTask(task backup.BackupTask) error {
-	if err := m.storage.CreateDirectory(filepath.Dir(task.Destination)); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
+// deltaCopier is the optional storage.Manager capability ExecuteTask uses
+// for large, already-present files instead of StoragePort's plain Copy.
+// It's a type assertion rather than a StoragePort method for the same
+// reason SetCache/CacheStats are: most StoragePort implementations (mocks,
+// tests) have no block-diffing to offer.
+type deltaCopier interface {
+	CopyDelta(ctx context.Context, src, dst string) (bytesTransferred, bytesReused int64, err error)
+}
+
+// tryDeltaCopy uses storage.Manager.CopyDelta instead of a whole-file Copy
+// when config.DeltaCopyThreshold is set, task is at or above it, and dst
+// already exists to diff against. It reports used=false - telling
+// ExecuteTask to fall back to a normal Copy - whenever any of those don't
+// hold, rather than treating them as errors.
+func (m *Manager) tryDeltaCopy(ctx context.Context, task backup.BackupTask) (used bool, err error) {
+	if m.config.DeltaCopyThreshold <= 0 || task.Size < m.config.DeltaCopyThreshold {
+		return false, nil
+	}
+
+	delta, ok := m.storage.(deltaCopier)
+	if !ok {
+		return false, nil
+	}
+
+	exists, err := m.storage.Exists(ctx, task.Destination)
+	if err != nil {
+		return false, fmt.Errorf("failed to check destination existence: %w", err)
+	}
+	if !exists {
+		return false, nil
 	}
 
-	if _, err := m.storage.Copy(task.Source, task.Destination, DefaultBufferSize); err != nil {
-		return fmt.Errorf("failed to copy file: %w", err)
+	if _, _, err := delta.CopyDelta(ctx, task.Source, task.Destination); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Manager) ExecuteTask(ctx context.Context, task backup.BackupTask) error {
+	if err := m.storage.CreateDirectory(ctx, filepath.Dir(task.Destination)); err != nil {
+		return taskerr.Classify(fmt.Errorf("failed to create destination directory: %w", err), task.Source, 0)
+	}
+
+	if m.config.ChunkedCopy {
+		if err := m.executeChunkedCopy(task); err != nil {
+			return err
+		}
+		if m.checkpoint != nil {
+			if err := m.checkpoint.Append(CheckpointRecord{
+				Path:    task.Source,
+				Size:    task.Size,
+				ModTime: task.ModTime.Unix(),
+			}); err != nil {
+				return taskerr.Classify(fmt.Errorf("failed to append checkpoint record: %w", err), task.Source, task.Size)
+			}
+		}
+		return nil
+	}
+
+	var bytesCopied int64
+	if used, err := m.tryDeltaCopy(ctx, task); err != nil {
+		return taskerr.Classify(fmt.Errorf("failed to delta-copy file: %w", err), task.Source, 0)
+	} else if !used {
+		n, err := m.storage.Copy(ctx, task.Source, task.Destination, DefaultBufferSize)
+		if err != nil {
+			return taskerr.Classify(fmt.Errorf("failed to copy file: %w", err), task.Source, n)
+		}
+		bytesCopied = n
 	}
 
 	if m.config.DeepDuplicateCheck {
-		if equal, err := m.compareChecksums(task); err != nil {
-			return fmt.Errorf("failed to verify copy: %w", err)
+		if equal, err := m.compareChecksums(ctx, task); err != nil {
+			return taskerr.Classify(fmt.Errorf("failed to verify copy: %w", err), task.Source, bytesCopied)
 		} else if !equal {
-			return fmt.Errorf("checksum mismatch for file: %s", task.Source)
+			return taskerr.Classify(fmt.Errorf("checksum mismatch for file: %s", task.Source), task.Source, bytesCopied)
+		}
+	}
+
+	if m.checkpoint != nil {
+		if err := m.checkpoint.Append(CheckpointRecord{
+			Path:    task.Source,
+			Size:    task.Size,
+			ModTime: task.ModTime.Unix(),
+		}); err != nil {
+			return taskerr.Classify(fmt.Errorf("failed to append checkpoint record: %w", err), task.Source, bytesCopied)
 		}
 	}
 
 	return nil
 }
 
+// ValidateTask isn't on TaskManagerPort's ctx-threaded run path - it has no
+// ctx of its own to pass Exists, so it uses context.Background() rather than
+// widening ValidateTask's signature for a quick existence check that isn't
+// itself interruptible.
 func (m *Manager) ValidateTask(task backup.BackupTask) error {
-	exists, err := m.storage.Exists(task.Source)
+	exists, err := m.storage.Exists(context.Background(), task.Source)
 	if err != nil {
 		return fmt.Errorf("failed to check source existence: %w", err)
 	}
@@ -0,0 +1,147 @@
+// Package taskerr classifies a backup task's failure into a stable code and
+// a retry category, so core/worker.Pool can decide whether a failure is
+// worth retrying without string-matching error messages, and so a run's
+// end-of-run summary can report what actually went wrong instead of just a
+// failed-file count.
+package taskerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Code names a stable failure reason, independent of the underlying error's
+// wording, so retry policy and a summary report can key off it directly.
+type Code string
+
+const (
+	ErrChecksumMismatch Code = "ErrChecksumMismatch"
+	ErrPermissionDenied Code = "ErrPermissionDenied"
+	ErrDiskFull         Code = "ErrDiskFull"
+	ErrSourceVanished   Code = "ErrSourceVanished"
+	ErrNetworkTimeout   Code = "ErrNetworkTimeout"
+	ErrConfigInvalid    Code = "ErrConfigInvalid"
+	// ErrUnknown is assigned to a failure Classify can't attribute to any of
+	// the above codes.
+	ErrUnknown Code = "ErrUnknown"
+)
+
+// Category groups a Code by whether retrying it is worth attempting.
+type Category string
+
+const (
+	// CategoryTransient failures may succeed on a later attempt without
+	// anything about the run changing - a momentarily full disk, a dropped
+	// connection - so these are the only category that consumes one of the
+	// run's configured retry attempts.
+	CategoryTransient Category = "transient"
+	// CategoryPermanent failures won't resolve by retrying: the source file
+	// is gone, the copied bytes don't match what was expected.
+	CategoryPermanent Category = "permanent"
+	// CategoryUser failures are caused by how the run was configured rather
+	// than anything about the file itself, and retrying won't fix a bad
+	// config.
+	CategoryUser Category = "user"
+)
+
+var categories = map[Code]Category{
+	ErrChecksumMismatch: CategoryPermanent,
+	ErrPermissionDenied: CategoryPermanent,
+	ErrDiskFull:         CategoryTransient,
+	ErrSourceVanished:   CategoryPermanent,
+	ErrNetworkTimeout:   CategoryTransient,
+	ErrConfigInvalid:    CategoryUser,
+	ErrUnknown:          CategoryPermanent,
+}
+
+// TaskError wraps a task failure with the Code and Category Classify
+// assigned it, plus the context a summary report needs: which file, how
+// much of it had already been copied, and how many attempts it took.
+type TaskError struct {
+	Code        Code
+	Category    Category
+	Path        string
+	BytesCopied int64
+	RetryCount  int
+	Err         error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%s: %s (attempt %d): %v", e.Code, e.Path, e.RetryCount, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetryCount returns a copy of e with RetryCount set to n, so
+// core/worker.Pool can record how many attempts a task took without
+// Classify itself needing to know about retries.
+func (e *TaskError) WithRetryCount(n int) *TaskError {
+	cp := *e
+	cp.RetryCount = n
+	return &cp
+}
+
+// Classify attributes err to a Code (falling back to ErrUnknown) and wraps
+// it as a *TaskError carrying path and bytesCopied. If err is already a
+// *TaskError - e.g. core/task.Manager.ExecuteTask already classified it -
+// it's returned unchanged rather than reclassified.
+func Classify(err error, path string, bytesCopied int64) *TaskError {
+	if err == nil {
+		return nil
+	}
+
+	var existing *TaskError
+	if errors.As(err, &existing) {
+		return existing
+	}
+
+	code := classifyCode(err)
+	return &TaskError{
+		Code:        code,
+		Category:    categories[code],
+		Path:        path,
+		BytesCopied: bytesCopied,
+		Err:         err,
+	}
+}
+
+// IsTransient reports whether err - classified first if it isn't already a
+// *TaskError - belongs to CategoryTransient, i.e. whether retrying it is
+// worth spending one of the run's configured retry attempts on.
+func IsTransient(err error) bool {
+	var terr *TaskError
+	if errors.As(err, &terr) {
+		return terr.Category == CategoryTransient
+	}
+	return categories[classifyCode(err)] == CategoryTransient
+}
+
+func classifyCode(err error) Code {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrNetworkTimeout
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrNetworkTimeout
+	}
+	if errors.Is(err, syscall.ENOSPC) {
+		return ErrDiskFull
+	}
+	if os.IsPermission(err) {
+		return ErrPermissionDenied
+	}
+	if os.IsNotExist(err) {
+		return ErrSourceVanished
+	}
+	if strings.Contains(err.Error(), "checksum mismatch") {
+		return ErrChecksumMismatch
+	}
+	return ErrUnknown
+}
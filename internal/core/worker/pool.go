@@ -8,18 +8,31 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jack-sneddon/backup-butler/internal/core/taskerr"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
 )
 
 const (
 	minWorkers       = 1
+	minRetryAttempts = 1
 	maxBackoffJitter = time.Second
 )
 
-// TaskExecutor defines the interface for task execution
+// TaskExecutor defines the interface for task execution. Both methods take a
+// context so a cancelled run can abort a skip check or copy already in
+// flight instead of waiting for it to finish.
 type TaskExecutor interface {
-	ExecuteTask(backup.BackupTask) error
-	ShouldSkipFile(backup.BackupTask) (bool, error)
+	ExecuteTask(ctx context.Context, task backup.BackupTask) error
+	ShouldSkipFile(ctx context.Context, task backup.BackupTask) (bool, error)
+}
+
+// Reporter receives task lifecycle events as the pool executes them, so a
+// caller can drive a live progress display without polling the result
+// channel alone. Both methods are called synchronously from worker
+// goroutines and must not block.
+type Reporter interface {
+	TaskStarted(workerID int, task backup.BackupTask)
+	TaskFinished(workerID int, result backup.TaskResult)
 }
 
 // Pool manages a pool of workers for concurrent task execution
@@ -28,6 +41,7 @@ type Pool struct {
 	taskExecutor  TaskExecutor
 	retryAttempts int
 	retryDelay    time.Duration
+	reporter      Reporter
 }
 
 // NewPool creates a new worker pool
@@ -35,6 +49,9 @@ func NewPool(workers int, executor TaskExecutor, retryAttempts int, retryDelay t
 	if workers < minWorkers {
 		workers = minWorkers
 	}
+	if retryAttempts < minRetryAttempts {
+		retryAttempts = minRetryAttempts
+	}
 
 	return &Pool{
 		workers:       workers,
@@ -44,6 +61,12 @@ func NewPool(workers int, executor TaskExecutor, retryAttempts int, retryDelay t
 	}
 }
 
+// SetReporter attaches a Reporter that is notified as each task starts and
+// finishes. Pass nil to detach.
+func (p *Pool) SetReporter(r Reporter) {
+	p.reporter = r
+}
+
 // Execute processes tasks using the worker pool
 func (p *Pool) Execute(ctx context.Context, tasks []backup.BackupTask) <-chan backup.TaskResult {
 	resultCh := make(chan backup.TaskResult, len(tasks))
@@ -53,7 +76,7 @@ func (p *Pool) Execute(ctx context.Context, tasks []backup.BackupTask) <-chan ba
 	var wg sync.WaitGroup
 	for i := 0; i < p.workers; i++ {
 		wg.Add(1)
-		go p.worker(ctx, &wg, taskCh, resultCh)
+		go p.worker(ctx, i, &wg, taskCh, resultCh)
 	}
 
 	// Feed tasks to workers
@@ -68,7 +91,29 @@ func (p *Pool) Execute(ctx context.Context, tasks []backup.BackupTask) <-chan ba
 	return resultCh
 }
 
-func (p *Pool) worker(ctx context.Context, wg *sync.WaitGroup, taskCh <-chan backup.BackupTask, resultCh chan<- backup.TaskResult) {
+// ExecuteStream behaves like Execute but consumes tasks from a channel
+// instead of a pre-built slice, so a caller that discovers tasks
+// incrementally (such as a streaming scan) can start copying the first file
+// before the rest have even been found. The result channel closes once
+// tasks closes and every in-flight task has finished.
+func (p *Pool) ExecuteStream(ctx context.Context, tasks <-chan backup.BackupTask) <-chan backup.TaskResult {
+	resultCh := make(chan backup.TaskResult, p.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go p.worker(ctx, i, &wg, tasks, resultCh)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	return resultCh
+}
+
+func (p *Pool) worker(ctx context.Context, workerID int, wg *sync.WaitGroup, taskCh <-chan backup.BackupTask, resultCh chan<- backup.TaskResult) {
 	defer wg.Done()
 
 	for task := range taskCh {
@@ -76,7 +121,13 @@ func (p *Pool) worker(ctx context.Context, wg *sync.WaitGroup, taskCh <-chan bac
 		case <-ctx.Done():
 			return
 		default:
+			if p.reporter != nil {
+				p.reporter.TaskStarted(workerID, task)
+			}
 			result := p.processTask(ctx, task)
+			if p.reporter != nil {
+				p.reporter.TaskFinished(workerID, result)
+			}
 			select {
 			case resultCh <- result:
 			case <-ctx.Done():
@@ -100,7 +151,7 @@ func (p *Pool) feedTasks(ctx context.Context, tasks []backup.BackupTask, taskCh
 
 func (p *Pool) processTask(ctx context.Context, task backup.BackupTask) backup.TaskResult {
 	// Check if task should be skipped
-	shouldSkip, err := p.taskExecutor.ShouldSkipFile(task)
+	shouldSkip, err := p.taskExecutor.ShouldSkipFile(ctx, task)
 	if err != nil {
 		return backup.TaskResult{
 			Task:   task,
@@ -133,33 +184,49 @@ func (p *Pool) processTask(ctx context.Context, task backup.BackupTask) backup.T
 	}
 }
 
+// executeWithRetry runs task through the executor, retrying on failure up
+// to retryAttempts times - but only a taskerr.CategoryTransient failure
+// actually consumes one of those attempts. A permanent or user failure
+// (a checksum mismatch, a missing source file, a bad config value) won't
+// resolve just because the run tries again, so it's returned immediately
+// instead of waiting out retryDelay for nothing.
 func (p *Pool) executeWithRetry(ctx context.Context, task backup.BackupTask) error {
-	var lastErr error
+	var lastErr *taskerr.TaskError
 
 	for attempt := 1; attempt <= p.retryAttempts; attempt++ {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			if err := p.taskExecutor.ExecuteTask(task); err == nil {
-				return nil
-			} else {
-				lastErr = err
-				if attempt < p.retryAttempts {
-					// Calculate backoff with jitter
-					backoff := p.retryDelay * time.Duration(attempt*attempt)
-					jitter := time.Duration(rand.Int63n(int64(maxBackoffJitter)))
-
-					select {
-					case <-ctx.Done():
-						return ctx.Err()
-					case <-time.After(backoff + jitter):
-						// Continue to next attempt
-					}
-				}
-			}
+		}
+
+		err := p.taskExecutor.ExecuteTask(ctx, task)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = taskerr.Classify(err, task.Source, 0).WithRetryCount(attempt)
+		if lastErr.Category != taskerr.CategoryTransient || attempt == p.retryAttempts {
+			break
+		}
+
+		// Calculate backoff with jitter
+		backoff := p.retryDelay * time.Duration(attempt*attempt)
+		jitter := time.Duration(rand.Int63n(int64(maxBackoffJitter)))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff + jitter):
+			// Continue to next attempt
 		}
 	}
 
-	return fmt.Errorf("task failed after %d attempts: %w", p.retryAttempts, lastErr)
+	if lastErr == nil {
+		// p.retryAttempts is clamped to minRetryAttempts in NewPool, so the
+		// loop above always runs at least once - this only guards a Pool
+		// constructed some other way with retryAttempts <= 0.
+		return fmt.Errorf("task failed: retryAttempts is %d, so ExecuteTask was never called", p.retryAttempts)
+	}
+	return fmt.Errorf("task failed after %d attempt(s): %w", lastErr.RetryCount, lastErr)
 }
@@ -24,6 +24,10 @@ type MetricsPort interface {
 	IncrementCompleted(bytes int64)
 	IncrementSkipped(bytes int64)
 	IncrementFailed()
+	// AddToTotal grows the run's denominator by files/bytes discovered after
+	// tracking started, so a progress bar fed by a streaming scan can grow
+	// its total as discovery proceeds instead of requiring an upfront count.
+	AddToTotal(files int, bytes int64)
 	GetStats() BackupStats
 	DisplayProgress()
 	DisplayFinalSummary()
@@ -41,14 +45,27 @@ type BackupService interface {
 	GetLatestVersion() (*BackupVersion, error)
 }
 
-// StoragePort defines storage operations
+// StoragePort defines storage operations. CalculateChecksum, Copy,
+// GetMetadata, Exists, and CreateDirectory take a context so a cancelled run
+// can abort an in-flight read or copy promptly instead of running it to
+// completion.
 type StoragePort interface {
-	CalculateChecksum(filePath string) (string, error)
-	Copy(src, dst string, bufferSize int) (int64, error)
-	Exists(path string) (bool, error)
-	GetMetadata(path string) (FileMetadata, error)
-	CreateDirectory(path string) error
+	CalculateChecksum(ctx context.Context, filePath string) (string, error)
+	Copy(ctx context.Context, src, dst string, bufferSize int) (int64, error)
+	Exists(ctx context.Context, path string) (bool, error)
+	GetMetadata(ctx context.Context, path string) (FileMetadata, error)
+	CreateDirectory(ctx context.Context, path string) error
 	IsDirectory(path string) (bool, error)
+	// Rename moves a file from oldPath to newPath without a content copy.
+	// Used to relocate an existing destination onto its canonical
+	// NFC-normalized form rather than leaving it in place and re-copying.
+	Rename(oldPath, newPath string) error
+	// SameLogicalPath reports whether a and b name the same file once both
+	// are Unicode-normalized, even if they differ byte-wise - e.g. the
+	// NFD-decomposed form filepath.Walk returns for a filename on macOS
+	// APFS versus the NFC-precomposed form of the identical name recorded
+	// in config.
+	SameLogicalPath(a, b string) bool
 }
 
 // VersionManagerPort handles backup versioning
@@ -56,6 +73,10 @@ type VersionManagerPort interface {
 	StartNewVersion(config *BackupConfig) *BackupVersion
 	AddFile(path string, metadata FileMetadata)
 	CompleteVersion(stats BackupStats) error
+	// CancelVersion saves the version in progress as cancelled rather than
+	// completed, so a run interrupted mid-backup leaves an honest record of
+	// what it managed to back up before the signal arrived.
+	CancelVersion(stats BackupStats) error
 	GetVersions() []BackupVersion
 	GetVersion(id string) (*BackupVersion, error)
 	GetLatestVersion() (*BackupVersion, error)
@@ -84,10 +105,12 @@ type ConfigLoaderPort interface {
 	Validate(config *BackupConfig) error
 }
 
-// TaskManagerPort handles backup task management
+// TaskManagerPort handles backup task management. CreateTasks, ShouldSkipFile,
+// and ExecuteTask take a context so the directory walk, metadata/checksum
+// reads, and file copy they perform can be aborted by a cancelled run.
 type TaskManagerPort interface {
-	CreateTasks(config *BackupConfig) ([]BackupTask, int, error)
-	ShouldSkipFile(task BackupTask) (bool, error)
-	ExecuteTask(task BackupTask) error
+	CreateTasks(ctx context.Context, config *BackupConfig) ([]BackupTask, int, error)
+	ShouldSkipFile(ctx context.Context, task BackupTask) (bool, error)
+	ExecuteTask(ctx context.Context, task BackupTask) error
 	ValidateTask(task BackupTask) error
 }
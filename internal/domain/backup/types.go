@@ -5,17 +5,46 @@ import "time"
 
 // BackupConfig represents the validated configuration for a backup operation
 type BackupConfig struct {
-	SourceDirectory    string         `yaml:"source_directory" json:"source_directory"`
-	TargetDirectory    string         `yaml:"target_directory" json:"target_directory"`
-	FoldersToBackup    []string       `yaml:"folders_to_backup" json:"folders_to_backup"`
-	DeepDuplicateCheck bool           `yaml:"deep_duplicate_check" json:"deep_duplicate_check"`
-	Concurrency        int            `yaml:"concurrency" json:"concurrency"`
-	BufferSize         int            `yaml:"buffer_size" json:"buffer_size"`
-	RetryAttempts      int            `yaml:"retry_attempts" json:"retry_attempts"`
-	RetryDelay         time.Duration  `yaml:"retry_delay" json:"retry_delay"`
-	ExcludePatterns    []string       `yaml:"exclude_patterns" json:"exclude_patterns"`
-	ChecksumAlgorithm  string         `yaml:"checksum_algorithm" json:"checksum_algorithm"`
-	LogLevel           string         `yaml:"log_level" json:"log_level"`
+	SourceDirectory    string        `yaml:"source_directory" json:"source_directory"`
+	TargetDirectory    string        `yaml:"target_directory" json:"target_directory"`
+	FoldersToBackup    []string      `yaml:"folders_to_backup" json:"folders_to_backup"`
+	DeepDuplicateCheck bool          `yaml:"deep_duplicate_check" json:"deep_duplicate_check"`
+	Concurrency        int           `yaml:"concurrency" json:"concurrency"`
+	BufferSize         int           `yaml:"buffer_size" json:"buffer_size"`
+	RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts"`
+	RetryDelay         time.Duration `yaml:"retry_delay" json:"retry_delay"`
+	ExcludePatterns    []string      `yaml:"exclude_patterns" json:"exclude_patterns"`
+	ChecksumAlgorithm  string        `yaml:"checksum_algorithm" json:"checksum_algorithm"`
+	LogLevel           string        `yaml:"log_level" json:"log_level"`
+	// NormalizeUnicode folds filenames to NFC when building destination paths
+	// and comparing source/destination for ShouldSkipFile, so a source tree
+	// walked off macOS APFS - which can return NFD-decomposed names - doesn't
+	// re-copy every file each run or leave an NFD sibling next to an existing
+	// NFC destination.
+	NormalizeUnicode bool `yaml:"normalize_unicode" json:"normalize_unicode"`
+	// ChunkedCopy backs files up as content-defined chunks instead of whole
+	// files: ExecuteTask splits the source, stores the chunks in a
+	// content-addressed pack directory under TargetDirectory, and
+	// ShouldSkipFile diffs chunk lists on later runs so an edit partway
+	// through a large file only re-transfers the chunks it touched.
+	ChunkedCopy bool `yaml:"chunked_copy" json:"chunked_copy"`
+	// PackSizeBytes is the target average chunk size ChunkedCopy cuts at;
+	// min/max bounds scale from it the same way version/chunkstore derives
+	// them (half for min, 8x for max). Zero uses storage/chunker's default
+	// (1MB average).
+	PackSizeBytes int `yaml:"pack_size_bytes" json:"pack_size_bytes"`
+	// ChecksumCache attaches a persistent (path, size, mtime, inode) ->
+	// checksum cache to the storage manager, so CalculateChecksum only
+	// re-hashes a file when its identity has changed since the last run
+	// instead of every time. See core/storage/cache.
+	ChecksumCache bool `yaml:"checksum_cache" json:"checksum_cache"`
+	// DeltaCopyThreshold makes ExecuteTask use storage.Manager.CopyDelta
+	// instead of a whole-file Copy for any source file at or above this
+	// size with an existing destination, reusing whichever destination
+	// blocks still match the source instead of re-transferring the whole
+	// file for a metadata-only edit. Zero (the default) disables delta
+	// copy entirely.
+	DeltaCopyThreshold int64          `yaml:"delta_copy_threshold" json:"delta_copy_threshold"`
 	Options            *ConfigOptions `yaml:"-" json:"-"`
 }
 
@@ -23,6 +52,7 @@ type BackupConfig struct {
 type ConfigOptions struct {
 	Verbose  bool   `yaml:"verbose" json:"verbose"`
 	Quiet    bool   `yaml:"quiet" json:"quiet"` // Keep this for controlling progress display
+	JSON     bool   `yaml:"json" json:"json"`   // Emit structured progress events instead of the live status UI
 	LogLevel string `yaml:"log_level" json:"log_level"`
 }
 
@@ -40,6 +70,21 @@ type FileMetadata struct {
 	Size     int64
 	ModTime  time.Time
 	Checksum string
+	// Chunks lists the content-defined chunks this file was split into when
+	// ChunkedCopy backed it up, in file order, so BackupVersion.Files
+	// carries a dedup-friendly manifest instead of only a whole-file
+	// checksum. Empty whenever ChunkedCopy didn't produce this file.
+	Chunks []ChunkRef
+}
+
+// ChunkRef locates one chunk of a file backed up via ChunkedCopy: which
+// content-addressed chunk (by hash) and where it belongs in the
+// reassembled file. It mirrors version/chunkstore.ChunkRef's shape without
+// this domain package depending on that storage-layer package.
+type ChunkRef struct {
+	Hash   string
+	Offset int64
+	Length int64
 }
 
 // BackupStats holds metrics about a backup operation
@@ -50,16 +95,68 @@ type BackupStats struct {
 	FilesFailed      int   // Number of files that failed to backup
 	TotalBytes       int64 // Total bytes processed
 	BytesTransferred int64 // Actual bytes copied
+	// CacheHits and CacheMisses count storage.Manager's persistent checksum
+	// cache lookups during this run, so a version's summary shows how much
+	// re-hashing the cache saved. Both stay zero when no cache is attached.
+	CacheHits   int
+	CacheMisses int
+	// BytesReused counts bytes CopyDelta took from the existing
+	// destination's matching blocks rather than from source's unmatched
+	// data (the whole source is still read to find the matches). Stays
+	// zero when delta copy never ran.
+	BytesReused int64
+}
+
+// EventType names a stage in Service.Backup's lifecycle. The engine emits a
+// stream of Events over a channel instead of calling a renderer directly, so
+// a Reporter (ui.Reporter) can consume the same stream whether it's drawing
+// a live terminal status block or writing line-delimited JSON to stdout.
+type EventType string
+
+const (
+	EventScanStarted       EventType = "scan_started"
+	EventFileStarted       EventType = "file_started"
+	EventFileCompleted     EventType = "file_completed"
+	EventFileSkipped       EventType = "file_skipped"
+	EventFileFailed        EventType = "file_failed"
+	EventDirectoryComplete EventType = "directory_complete"
+	EventVersionComplete   EventType = "version_complete"
+)
+
+// Event is one point in Service.Backup's progress stream. Only the fields
+// relevant to Type are populated - the comment above each field group below
+// names the event types that set it.
+type Event struct {
+	Type EventType
+
+	// WorkerID, Path, Bytes, Checksum, Err: FileStarted/FileCompleted/
+	// FileSkipped/FileFailed. Err is only set for FileFailed.
+	WorkerID int
+	Path     string
+	Bytes    int64
+	Checksum string
+	Err      error
+
+	// FilesTotal, BytesTotal: ScanStarted.
+	FilesTotal int
+	BytesTotal int64
+
+	// Directory: DirectoryComplete.
+	Directory string
+
+	// Stats: VersionComplete.
+	Stats BackupStats
 }
 
 // BackupVersion represents a completed backup operation
 type BackupVersion struct {
-	ID         string                  // Unique identifier (timestamp-based)
-	Timestamp  time.Time               // When backup was performed
-	Files      map[string]FileMetadata // Map of path to file metadata
-	Size       int64                   // Total size of backup
-	Status     string                  // Success, Failed, Partial
-	Duration   time.Duration           // How long the backup took
-	Stats      BackupStats             // Additional statistics
-	ConfigUsed BackupConfig            // Configuration used for this backup
+	ID          string                  // Unique identifier (timestamp-based)
+	Timestamp   time.Time               // When backup was performed
+	Files       map[string]FileMetadata // Map of path to file metadata
+	Size        int64                   // Total size of backup
+	Status      string                  // In Progress, Completed, Cancelled
+	Duration    time.Duration           // How long the backup took
+	Stats       BackupStats             // Additional statistics
+	ConfigUsed  BackupConfig            // Configuration used for this backup
+	ScanStateID string                  // ID of the scan.ScanState snapshot this version's file list was discovered from, if any
 }
@@ -0,0 +1,30 @@
+// Package fs abstracts the handful of filesystem operations the backup
+// and progress-tracking code needs behind an interface, so that code can
+// run against an in-memory fake instead of the real disk.
+package fs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// File is the subset of *os.File operations callers need after Open or
+// Create.
+type File interface {
+	io.ReadWriteCloser
+	Stat() (os.FileInfo, error)
+}
+
+// Filesystem is the set of filesystem operations task.Manager,
+// progress.tracker, and FilesystemLogger perform, abstracted so they can
+// be pointed at an in-memory fake instead of the real disk.
+type Filesystem interface {
+	Open(name string) (File, error)
+	Create(name string) (File, error)
+	Stat(name string) (os.FileInfo, error)
+	Walk(root string, fn filepath.WalkFunc) error
+	MkdirAll(path string, perm os.FileMode) error
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+}
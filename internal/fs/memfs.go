@@ -0,0 +1,251 @@
+package fs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mem is an in-memory Filesystem, for exercising scanFolder/ScanDirectory/
+// FilesystemLogger style code against deterministic exclude patterns,
+// permission errors, and partial writes without touching real disk.
+type Mem struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+}
+
+type memNode struct {
+	isDir   bool
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// NewMem creates an empty in-memory Filesystem containing only the root
+// directory "/".
+func NewMem() *Mem {
+	return &Mem{
+		nodes: map[string]*memNode{
+			"/": {isDir: true, mode: os.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+func clean(name string) string {
+	if name == "" {
+		return "/"
+	}
+	return filepath.ToSlash(filepath.Clean(name))
+}
+
+func (m *Mem) parentDir(path string) string {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if dir == "." {
+		return "/"
+	}
+	return dir
+}
+
+func (m *Mem) Open(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	node, ok := m.nodes[name]
+	if !ok || node.isDir {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+
+	return &memFile{name: name, node: node, reader: bytes.NewReader(node.data)}, nil
+}
+
+func (m *Mem) Create(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	parent := m.parentDir(name)
+	if parentNode, ok := m.nodes[parent]; !ok || !parentNode.isDir {
+		return nil, &os.PathError{Op: "create", Path: name, Err: os.ErrNotExist}
+	}
+
+	node := &memNode{mode: 0644, modTime: time.Now()}
+	m.nodes[name] = node
+
+	return &memFile{name: name, node: node, writable: true}, nil
+}
+
+func (m *Mem) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+
+	return memFileInfo{name: filepath.Base(name), node: node}, nil
+}
+
+func (m *Mem) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = clean(path)
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	current := "/"
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		current = clean(current + "/" + part)
+		if node, ok := m.nodes[current]; ok {
+			if !node.isDir {
+				return &os.PathError{Op: "mkdir", Path: current, Err: fmt.Errorf("not a directory")}
+			}
+			continue
+		}
+		m.nodes[current] = &memNode{isDir: true, mode: os.ModeDir | perm, modTime: time.Now()}
+	}
+
+	return nil
+}
+
+func (m *Mem) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldpath, newpath = clean(oldpath), clean(newpath)
+	node, ok := m.nodes[oldpath]
+	if !ok {
+		return &os.PathError{Op: "rename", Path: oldpath, Err: os.ErrNotExist}
+	}
+
+	delete(m.nodes, oldpath)
+	m.nodes[newpath] = node
+
+	return nil
+}
+
+func (m *Mem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	if _, ok := m.nodes[name]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	delete(m.nodes, name)
+
+	return nil
+}
+
+// Walk visits root and every path nested under it, shallowest first, in the
+// same depth-first, lexical order as filepath.Walk.
+func (m *Mem) Walk(root string, fn filepath.WalkFunc) error {
+	root = clean(root)
+
+	m.mu.Lock()
+	info, ok := m.nodes[root]
+	m.mu.Unlock()
+	if !ok {
+		return fn(root, nil, &os.PathError{Op: "walk", Path: root, Err: os.ErrNotExist})
+	}
+
+	return m.walk(root, memFileInfo{name: filepath.Base(root), node: info}, fn)
+}
+
+func (m *Mem) walk(path string, info os.FileInfo, fn filepath.WalkFunc) error {
+	if err := fn(path, info, nil); err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	for _, child := range m.children(path) {
+		m.mu.Lock()
+		node := m.nodes[child]
+		m.mu.Unlock()
+
+		childInfo := memFileInfo{name: filepath.Base(child), node: node}
+		if err := m.walk(child, childInfo, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Mem) children(dir string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix := dir
+	if prefix != "/" {
+		prefix += "/"
+	}
+
+	var children []string
+	for path := range m.nodes {
+		if path == dir || !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		if strings.Contains(strings.TrimPrefix(path, prefix), "/") {
+			continue // not a direct child
+		}
+		children = append(children, path)
+	}
+	sort.Strings(children)
+
+	return children
+}
+
+type memFile struct {
+	name     string
+	node     *memNode
+	reader   *bytes.Reader
+	writable bool
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.reader == nil {
+		return 0, &os.PathError{Op: "read", Path: f.name, Err: fmt.Errorf("file not open for reading")}
+	}
+	return f.reader.Read(p)
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	if !f.writable {
+		return 0, &os.PathError{Op: "write", Path: f.name, Err: fmt.Errorf("file not open for writing")}
+	}
+	f.node.data = append(f.node.data, p...)
+	f.node.modTime = time.Now()
+	return len(p), nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+func (f *memFile) Stat() (os.FileInfo, error) {
+	return memFileInfo{name: filepath.Base(f.name), node: f.node}, nil
+}
+
+type memFileInfo struct {
+	name string
+	node *memNode
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return int64(len(i.node.data)) }
+func (i memFileInfo) Mode() os.FileMode  { return i.node.mode }
+func (i memFileInfo) ModTime() time.Time { return i.node.modTime }
+func (i memFileInfo) IsDir() bool        { return i.node.isDir }
+func (i memFileInfo) Sys() interface{}   { return nil }
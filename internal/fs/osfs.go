@@ -0,0 +1,42 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// osFilesystem implements Filesystem directly against the real disk.
+type osFilesystem struct{}
+
+// OS returns the Filesystem backed by the real disk.
+func OS() Filesystem {
+	return osFilesystem{}
+}
+
+func (osFilesystem) Open(name string) (File, error) {
+	return os.Open(name)
+}
+
+func (osFilesystem) Create(name string) (File, error) {
+	return os.Create(name)
+}
+
+func (osFilesystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFilesystem) Walk(root string, fn filepath.WalkFunc) error {
+	return filepath.Walk(root, fn)
+}
+
+func (osFilesystem) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFilesystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+func (osFilesystem) Remove(name string) error {
+	return os.Remove(name)
+}
@@ -0,0 +1,35 @@
+// Package lazy defers running a fallible constructor until its result is
+// first needed, then caches it - a sync.Once wrapped around a
+// func() (T, error) - so a component that's expensive to build or can
+// fail outright (a remote storage backend dialing out, a version
+// manifest read) only pays that cost, and only surfaces that failure,
+// once something actually uses it. app.Factory uses this so --dry-run,
+// config validation, and a health check can each touch only the
+// subsystems they need instead of paying for full initialization.
+package lazy
+
+import "sync"
+
+// Lazy runs init at most once, on the first Get, and returns that same
+// cached (value, error) pair to every later caller.
+type Lazy[T any] struct {
+	once  sync.Once
+	init  func() (T, error)
+	value T
+	err   error
+}
+
+// New wraps init so it runs on the first Get rather than immediately.
+func New[T any](init func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// Get triggers init on the first call; every subsequent call returns the
+// same cached result without calling init again, even if init previously
+// returned an error.
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		l.value, l.err = l.init()
+	})
+	return l.value, l.err
+}
@@ -3,6 +3,7 @@ package progress
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -12,11 +13,13 @@ import (
 
 type display struct {
 	progress *Progress
+	status   *termStatus
 }
 
 func NewDisplay(p *Progress) *display {
 	return &display{
 		progress: p,
+		status:   newTermStatus(os.Stdout),
 	}
 }
 
@@ -25,6 +28,7 @@ func (d *display) Start() {
 }
 
 func (d *display) Stop() {
+	d.status.Close()
 	logger.Debug("Display stopping")
 }
 
@@ -38,35 +42,34 @@ func (d *display) render() {
 		return
 	}
 
-	// Current directory - match test expectation
-	fmt.Printf("\nProcessing: %s\n", d.progress.Current.Path)
-
-	// Progress bar
 	pct := 0.0
 	if d.progress.Current.Total > 0 {
 		pct = float64(d.progress.Current.Processed) / float64(d.progress.Current.Total) * 100
 	}
-	fmt.Printf("[%s] %.1f%% (%d/%d files)\n",
-		renderBar(pct, 40),
-		pct,
-		d.progress.Current.Done,
-		d.progress.Current.Files)
 
-	fmt.Printf("Currently Processing:\n")
-	fmt.Printf("  %s (%.1f MB)\n",
-		filepath.Base(d.progress.Current.Path),
-		float64(d.progress.Current.Total)/(1024*1024))
+	lines := []string{
+		fmt.Sprintf("Processing: %s", d.progress.Current.Path),
+		fmt.Sprintf("[%s] %.1f%% (%d/%d files)",
+			renderBar(pct, 40),
+			pct,
+			d.progress.Current.Done,
+			d.progress.Current.Files),
+		"Currently Processing:",
+		fmt.Sprintf("  %s (%.1f MB)",
+			filepath.Base(d.progress.Current.Path),
+			float64(d.progress.Current.Total)/(1024*1024)),
+		"Statistics:",
+		fmt.Sprintf("├── Processed: %d files (%.1f GB)",
+			d.progress.Processed,
+			float64(d.progress.BytesDone)/(1024*1024*1024)),
+		fmt.Sprintf("├── Remaining: %d files (%.1f GB)",
+			d.progress.TotalFiles-d.progress.Processed,
+			float64(d.progress.TotalBytes-d.progress.BytesDone)/(1024*1024*1024)),
+		fmt.Sprintf("└── Total Time: %s",
+			time.Since(d.progress.StartTime).Round(time.Second)),
+	}
 
-	// Statistics
-	fmt.Printf("\nStatistics:\n")
-	fmt.Printf("├── Processed: %d files (%.1f GB)\n",
-		d.progress.Processed,
-		float64(d.progress.BytesDone)/(1024*1024*1024))
-	fmt.Printf("├── Remaining: %d files (%.1f GB)\n",
-		d.progress.TotalFiles-d.progress.Processed,
-		float64(d.progress.TotalBytes-d.progress.BytesDone)/(1024*1024*1024))
-	fmt.Printf("└── Total Time: %s\n\n", // Changed from "Time" to "Total Time"
-		time.Since(d.progress.StartTime).Round(time.Second))
+	d.status.Redraw(lines)
 }
 
 func renderBar(percent float64, width int) string {
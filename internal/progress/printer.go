@@ -0,0 +1,180 @@
+// internal/progress/printer.go
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ProgressPrinter is the sink every progress-reporting call site writes
+// through, so the scanner and metrics layers don't have to choose between
+// fmt.Printf and a JSON encoder themselves - a TextPrinter renders the
+// interactive bar, a JSONPrinter emits one line-delimited event per call,
+// and both honor the same call surface.
+type ProgressPrinter interface {
+	// ScannerError reports a non-fatal error encountered while walking the
+	// source tree, without aborting the run.
+	ScannerError(path string, err error)
+	// StartFile announces that path is now being processed.
+	StartFile(path string)
+	// CompleteItem reports the outcome of the file most recently started.
+	CompleteItem(path, status string, bytes int64)
+	// ReportTotal redraws the aggregate progress line.
+	ReportTotal(filesDone, filesTotal int, bytesDone, bytesTotal int64, rate float64)
+	// Finish reports the run's final summary.
+	Finish(filesDone, filesSkipped, filesFailed int, bytesDone int64, duration time.Duration)
+	// Stdout and Stderr are the writers StartFile/CompleteItem/ReportTotal
+	// and ScannerError/errors write to, respectively - exposed so a caller
+	// that still needs to fmt.Fprint something directly goes through the
+	// same destination as the printer.
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
+// event is the JSON shape JSONPrinter emits, one object per line.
+type event struct {
+	Type         string  `json:"type"` // "status", "error", or "summary"
+	Phase        string  `json:"phase,omitempty"`
+	Path         string  `json:"path,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	Error        string  `json:"error,omitempty"`
+	FilesDone    int     `json:"files_done,omitempty"`
+	FilesTotal   int     `json:"files_total,omitempty"`
+	FilesSkipped int     `json:"files_skipped,omitempty"`
+	FilesFailed  int     `json:"files_failed,omitempty"`
+	BytesDone    int64   `json:"bytes_done,omitempty"`
+	BytesTotal   int64   `json:"bytes_total,omitempty"`
+	BytesPerSec  float64 `json:"bytes_per_sec,omitempty"`
+	ETASeconds   float64 `json:"eta_seconds,omitempty"`
+	DurationSecs float64 `json:"duration_seconds,omitempty"`
+}
+
+// JSONPrinter emits one line-delimited JSON event per call on stdout (error
+// events on stderr), for
+// `backup-butler backup --json` callers that want stable, machine-parseable
+// progress instead of an ANSI-redrawn bar.
+type JSONPrinter struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// NewJSONPrinter returns a JSONPrinter writing events to stdout and errors
+// (also as JSON "error" events) to stderr.
+func NewJSONPrinter(stdout, stderr io.Writer) *JSONPrinter {
+	return &JSONPrinter{stdout: stdout, stderr: stderr}
+}
+
+func (p *JSONPrinter) emit(e event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(p.stdout, string(data))
+}
+
+func (p *JSONPrinter) ScannerError(path string, err error) {
+	data, mErr := json.Marshal(event{Type: "error", Path: path, Error: err.Error()})
+	if mErr != nil {
+		return
+	}
+	fmt.Fprintln(p.stderr, string(data))
+}
+
+func (p *JSONPrinter) StartFile(path string) {
+	p.emit(event{Type: "status", Phase: "processing", Path: path})
+}
+
+func (p *JSONPrinter) CompleteItem(path, status string, bytes int64) {
+	p.emit(event{Type: "status", Phase: "processing", Path: path, Status: status, BytesDone: bytes})
+}
+
+func (p *JSONPrinter) ReportTotal(filesDone, filesTotal int, bytesDone, bytesTotal int64, rate float64) {
+	var eta float64
+	if remaining := bytesTotal - bytesDone; rate > 0 && remaining > 0 {
+		eta = float64(remaining) / rate
+	}
+	p.emit(event{
+		Type:        "status",
+		Phase:       "processing",
+		FilesDone:   filesDone,
+		FilesTotal:  filesTotal,
+		BytesDone:   bytesDone,
+		BytesTotal:  bytesTotal,
+		BytesPerSec: rate,
+		ETASeconds:  eta,
+	})
+}
+
+func (p *JSONPrinter) Finish(filesDone, filesSkipped, filesFailed int, bytesDone int64, duration time.Duration) {
+	p.emit(event{
+		Type:         "summary",
+		FilesDone:    filesDone,
+		FilesSkipped: filesSkipped,
+		FilesFailed:  filesFailed,
+		BytesDone:    bytesDone,
+		DurationSecs: duration.Seconds(),
+	})
+}
+
+func (p *JSONPrinter) Stdout() io.Writer { return p.stdout }
+func (p *JSONPrinter) Stderr() io.Writer { return p.stderr }
+
+// TextPrinter renders the same interactive, redrawn-in-place bar
+// core/monitoring.Metrics and internal/progress always have, just behind
+// the ProgressPrinter interface instead of calling fmt.Print directly.
+type TextPrinter struct {
+	stdout io.Writer
+	stderr io.Writer
+	width  int
+}
+
+// NewTextPrinter returns a TextPrinter drawing a bar barWidth characters
+// wide to stdout, with errors on stderr.
+func NewTextPrinter(stdout, stderr io.Writer, barWidth int) *TextPrinter {
+	return &TextPrinter{stdout: stdout, stderr: stderr, width: barWidth}
+}
+
+func (p *TextPrinter) ScannerError(path string, err error) {
+	fmt.Fprintf(p.stderr, "ERROR: %s: %v\n", path, err)
+}
+
+func (p *TextPrinter) StartFile(path string) {
+	fmt.Fprintf(p.stdout, "Processing: %s\n", path)
+}
+
+func (p *TextPrinter) CompleteItem(path, status string, bytes int64) {
+	// Intentionally silent: ReportTotal redraws the aggregate line right
+	// after, so a per-file line here would just scroll past it.
+}
+
+func (p *TextPrinter) ReportTotal(filesDone, filesTotal int, bytesDone, bytesTotal int64, rate float64) {
+	pct := 0.0
+	if bytesTotal > 0 {
+		pct = float64(bytesDone) / float64(bytesTotal) * 100
+	}
+	filled := int(float64(p.width) * pct / 100)
+	filled = min(filled, p.width)
+	bar := strings.Repeat("=", filled)
+	if filled < p.width {
+		bar += ">" + strings.Repeat(" ", p.width-filled-1)
+	}
+
+	fmt.Fprint(p.stdout, "\x1b[s")     // Save cursor position
+	fmt.Fprint(p.stdout, "\x1b[1000D") // Move cursor far left
+	fmt.Fprint(p.stdout, "\x1b[K")     // Clear line
+	fmt.Fprintf(p.stdout, "[%s] %5.1f%% | %d/%d files | %.2f MB/s",
+		bar, pct, filesDone, filesTotal, rate/(1024*1024))
+	fmt.Fprint(p.stdout, "\x1b[u") // Restore cursor position
+}
+
+func (p *TextPrinter) Finish(filesDone, filesSkipped, filesFailed int, bytesDone int64, duration time.Duration) {
+	fmt.Fprintf(p.stdout, "\n\nBackup completed in %v\n", duration)
+	fmt.Fprintf(p.stdout, "Files processed: %d, Files skipped: %d, Failed: %d, Total size: %.2f MB\n",
+		filesDone, filesSkipped, filesFailed, float64(bytesDone)/(1024*1024))
+}
+
+func (p *TextPrinter) Stdout() io.Writer { return p.stdout }
+func (p *TextPrinter) Stderr() io.Writer { return p.stderr }
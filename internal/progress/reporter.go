@@ -0,0 +1,158 @@
+// internal/progress/reporter.go
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// reporterEWMAAlpha weights the newest per-file throughput sample against
+// the running average used for ReportTotal's rate, the same smoothing
+// constant the rest of this codebase uses for transfer-rate EWMAs.
+const reporterEWMAAlpha = 0.3
+
+// defaultMinUpdatePause is how often Run redraws by default.
+const defaultMinUpdatePause = 200 * time.Millisecond
+
+// Reporter holds the mutex-protected counters the scan phase and the
+// backup phase both push updates into, and a Run loop that redraws them
+// through a ProgressPrinter no more often than the configured pause. It
+// replaces having Scanner and Metrics each drive their own ad-hoc
+// fmt.Printf/display calls, so one reporter can show scan totals growing
+// during the count pass and then per-file transfer progress during the
+// backup pass, without the caller juggling two separate displays.
+type Reporter struct {
+	mu sync.Mutex
+
+	printer        ProgressPrinter
+	minUpdatePause time.Duration
+
+	scanning   bool
+	totalFiles int
+	totalBytes int64
+
+	filesDone   int
+	bytesDone   int64
+	currentPath string
+	rate        float64
+	lastUpdate  time.Time
+	dirty       bool
+}
+
+// NewReporter creates a Reporter that draws through printer.
+func NewReporter(printer ProgressPrinter) *Reporter {
+	return &Reporter{printer: printer, minUpdatePause: defaultMinUpdatePause}
+}
+
+// SetMinUpdatePause overrides the default pause between Run's redraws.
+func (r *Reporter) SetMinUpdatePause(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.minUpdatePause = d
+}
+
+// UpdateScanTotals records the scanner's running file/byte totals as it
+// discovers them, so a display reading these totals grows during the count
+// pass instead of staying at zero until counting finishes.
+func (r *Reporter) UpdateScanTotals(totalFiles int, totalBytes int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scanning = true
+	r.totalFiles = totalFiles
+	r.totalBytes = totalBytes
+	r.dirty = true
+}
+
+// ScanComplete marks the count pass done; later totals only change via
+// StartFile/CompleteFile during the backup pass.
+func (r *Reporter) ScanComplete() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scanning = false
+	r.dirty = true
+}
+
+// ScannerError reports a non-fatal error encountered while walking the
+// source tree.
+func (r *Reporter) ScannerError(path string, err error) {
+	r.printer.ScannerError(path, err)
+}
+
+// StartFile announces that path is now being processed.
+func (r *Reporter) StartFile(path string) {
+	r.mu.Lock()
+	r.currentPath = path
+	r.dirty = true
+	r.mu.Unlock()
+
+	r.printer.StartFile(path)
+}
+
+// CompleteFile records a finished file's outcome and refreshes the
+// throughput rate Run's redraws use for the aggregate line.
+func (r *Reporter) CompleteFile(status string, bytes int64) {
+	r.mu.Lock()
+	now := time.Now()
+	if !r.lastUpdate.IsZero() {
+		if elapsed := now.Sub(r.lastUpdate).Seconds(); elapsed > 0 {
+			instant := float64(bytes) / elapsed
+			r.rate = reporterEWMAAlpha*instant + (1-reporterEWMAAlpha)*r.rate
+		}
+	}
+	r.lastUpdate = now
+	r.filesDone++
+	r.bytesDone += bytes
+	path := r.currentPath
+	r.dirty = true
+	r.mu.Unlock()
+
+	r.printer.CompleteItem(path, status, bytes)
+}
+
+// Run redraws the aggregate progress line through the printer until ctx is
+// cancelled, waking no more often than the configured minUpdatePause and
+// skipping a redraw when nothing has changed since the last one.
+func (r *Reporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pause())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.render()
+		}
+	}
+}
+
+func (r *Reporter) pause() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.minUpdatePause
+}
+
+func (r *Reporter) render() {
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return
+	}
+	filesDone, totalFiles := r.filesDone, r.totalFiles
+	bytesDone, totalBytes := r.bytesDone, r.totalBytes
+	rate := r.rate
+	r.dirty = false
+	r.mu.Unlock()
+
+	r.printer.ReportTotal(filesDone, totalFiles, bytesDone, totalBytes, rate)
+}
+
+// Finish reports the run's final summary through the printer.
+func (r *Reporter) Finish(filesSkipped, filesFailed int, duration time.Duration) {
+	r.mu.Lock()
+	filesDone, bytesDone := r.filesDone, r.bytesDone
+	r.mu.Unlock()
+
+	r.printer.Finish(filesDone, filesSkipped, filesFailed, bytesDone, duration)
+}
@@ -0,0 +1,61 @@
+// internal/progress/termstatus.go
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// termStatus redraws a fixed block of lines in place, the way BuildKit's
+// tty progress UI repaints the active build steps instead of scrolling the
+// terminal. It falls back to plain, non-overwriting output when stdout
+// isn't a terminal (piped output, CI logs, etc.) so the same render calls
+// work either way.
+type termStatus struct {
+	out       io.Writer
+	isTTY     bool
+	lastLines int
+}
+
+func newTermStatus(out *os.File) *termStatus {
+	return &termStatus{
+		out:   out,
+		isTTY: isTerminal(out),
+	}
+}
+
+// isTerminal reports whether out is an interactive terminal rather than a
+// pipe or redirected file, without pulling in a terminal-detection
+// dependency for what's otherwise a single stat call.
+func isTerminal(out *os.File) bool {
+	info, err := out.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Redraw replaces the previously drawn block with lines. When output isn't
+// a terminal it just appends lines, since there's no cursor to move.
+func (t *termStatus) Redraw(lines []string) {
+	if !t.isTTY {
+		fmt.Fprintln(t.out, strings.Join(lines, "\n"))
+		return
+	}
+
+	if t.lastLines > 0 {
+		fmt.Fprintf(t.out, "\033[%dA", t.lastLines)
+	}
+	for _, line := range lines {
+		fmt.Fprint(t.out, "\033[2K", line, "\n")
+	}
+	t.lastLines = len(lines)
+}
+
+// Close leaves the cursor below the last rendered block so whatever prints
+// next (a final summary, an error) starts on its own line.
+func (t *termStatus) Close() {
+	t.lastLines = 0
+}
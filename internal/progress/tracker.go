@@ -4,16 +4,17 @@ package progress
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/jack-sneddon/backup-butler/internal/fs"
 	"github.com/jack-sneddon/backup-butler/internal/logger"
 )
 
 type tracker struct {
 	progress *Progress
 	display  *display
+	fs       fs.Filesystem
 	mu       sync.Mutex
 }
 
@@ -26,9 +27,16 @@ func NewTracker() *tracker {
 	return &tracker{
 		progress: p,
 		display:  NewDisplay(p),
+		fs:       fs.OS(),
 	}
 }
 
+// SetFilesystem swaps the Filesystem ScanDirectory walks, so a caller can
+// point it at an in-memory fake instead of the real disk.
+func (t *tracker) SetFilesystem(filesystem fs.Filesystem) {
+	t.fs = filesystem
+}
+
 func (t *tracker) Start() error {
 	logger.Debug("Starting progress tracker")
 
@@ -109,7 +117,7 @@ func (t *tracker) ScanDirectory(path string) error {
 	var totalFiles int
 	var totalBytes int64
 
-	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+	err := t.fs.Walk(path, func(filePath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -2,20 +2,24 @@
 package scan
 
 import (
-	"crypto/sha256"
+	"context"
+	"encoding/binary"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
 	"github.com/jack-sneddon/backup-butler/internal/types"
+	"golang.org/x/sync/errgroup"
 )
 
-func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
+func (s *Scanner) Compare(ctx context.Context, source, target string) ([]*FileComparison, error) {
 	s.stats = make(map[string]*DirectoryStats)
 
 	// Scan source
-	_, err := s.Scan(source)
+	_, err := s.Scan(ctx, source)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +27,7 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 
 	// Reset and scan target
 	s.stats = make(map[string]*DirectoryStats)
-	_, err = s.Scan(target)
+	_, err = s.Scan(ctx, target)
 	if err != nil {
 		return nil, err
 	}
@@ -31,9 +35,18 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 
 	comparisons := make([]*FileComparison, 0)
 
+	// toCompare collects every comparison that needs compareFiles' content
+	// hashing - the expensive part - so it can run across a worker pool
+	// instead of one file at a time on this goroutine. Anything resolved
+	// here (no target match) is already final.
+	var toCompare []*FileComparison
+
 	// Compare source files to target
 	for _, dir := range sourceStats {
 		for _, file := range dir.Files {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			relPath, err := filepath.Rel(source, file.Path)
 			if err != nil {
 				logger.Debug("Error getting relative path",
@@ -57,9 +70,9 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 				Level:  types.ValidationLevel(validationLevel),
 			}
 
-			if tf := findFile(targetStats, targetPath); tf != nil {
+			if tf := findFile(targetStats, targetPath, !s.opts.DisableUnicodeNormalization); tf != nil {
 				comp.Target = tf
-				comp.Status = s.compareFiles(file, tf, comp.Level)
+				toCompare = append(toCompare, comp)
 			} else {
 				comp.Status = StatusNew
 			}
@@ -70,6 +83,10 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 	// Find target-only files
 	for _, dir := range targetStats {
 		for _, file := range dir.Files {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
 			relPath, err := filepath.Rel(target, file.Path)
 			if err != nil {
 				logger.Debug("Error getting relative path for target file",
@@ -82,7 +99,7 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 				continue
 			}
 
-			if findFile(sourceStats, filepath.Join(source, relPath)) == nil {
+			if findFile(sourceStats, filepath.Join(source, relPath), !s.opts.DisableUnicodeNormalization) == nil {
 				comparisons = append(comparisons, &FileComparison{
 					Path:   relPath,
 					Target: file,
@@ -92,24 +109,107 @@ func (s *Scanner) Compare(source, target string) ([]*FileComparison, error) {
 		}
 	}
 
+	if err := s.compareConcurrently(ctx, toCompare); err != nil {
+		return nil, err
+	}
+
 	return comparisons, nil
 }
 
+// compareConcurrently runs compareFiles for every job in a bounded worker
+// pool instead of one at a time on the caller's goroutine, so a run with
+// many Standard/Deep/Chunked comparisons spreads their hashing across every
+// available core. Pool size comes from ScannerOptions.Concurrency,
+// defaulting to runtime.NumCPU(). Each job is a distinct *FileComparison
+// only its own worker ever touches, so no further locking is needed around
+// comp.Status. When a reporter is attached (see SetReporter), workers push
+// StartFile/CompleteFile into it exactly as the backup phase does, so a
+// caller gets the same live throughput display during comparison.
+func (s *Scanner) compareConcurrently(ctx context.Context, jobs []*FileComparison) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	workers := s.opts.Concurrency
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	jobCh := make(chan *FileComparison)
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case <-gctx.Done():
+				return gctx.Err()
+			case jobCh <- job:
+			}
+		}
+		return nil
+	})
+
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for job := range jobCh {
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+
+				if s.reporter != nil {
+					s.reporter.StartFile(job.Path)
+				}
+				job.Status = s.compareFiles(job.Source, job.Target, job.Level)
+				if s.reporter != nil {
+					s.reporter.CompleteFile(string(job.Status), job.Source.Size)
+				}
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// determineValidationLevel looks path up against ScannerOptions.LevelRules
+// (first match wins, "**"-capable glob patterns matched against the path
+// relative to the scan root) and falls back to s.opts.Level when no rule
+// matches or none are configured.
 func (s *Scanner) determineValidationLevel(path string) types.ValidationLevel {
-	// Return the default validation level
+	if s.levelMatcher != nil {
+		if level, ok := s.levelMatcher.Level(path); ok {
+			logger.Debug("Using rule-matched validation level",
+				"path", path,
+				"level", level)
+			return level
+		}
+	}
+
 	logger.Debug("Using default validation level",
 		"path", path,
 		"level", s.opts.Level)
 	return s.opts.Level
 }
 
-func findFile(stats map[string]*DirectoryStats, path string) *FileInfo {
+// findFile looks up path within stats, the same directory-keyed layout Scan
+// builds. When normalize is true (the default - see
+// ScannerOptions.DisableUnicodeNormalization), both sides of the comparison
+// are NFC-folded first, so a file whose name differs from path only in
+// Unicode normalization form still matches.
+func findFile(stats map[string]*DirectoryStats, path string, normalize bool) *FileInfo {
 	dir := filepath.Dir(path)
 	if dirStat, ok := stats[dir]; ok {
 		for _, file := range dirStat.Files {
 			if file.Path == path {
 				return file
 			}
+			if normalize && normalizeKey(file.Path) == normalizeKey(path) {
+				return file
+			}
 		}
 	}
 	return nil
@@ -140,6 +240,11 @@ func (s *Scanner) compareFiles(src, tgt *FileInfo, level types.ValidationLevel)
 		return StatusMatch
 	}
 
+	algo := s.opts.HashAlgorithm
+	if algo == hash.None {
+		algo = hash.SHA256
+	}
+
 	// Standard validation: 32KB hash
 	if level == types.Standard {
 		bufferSize := s.opts.ValidationConfig.BufferSize
@@ -147,12 +252,12 @@ func (s *Scanner) compareFiles(src, tgt *FileInfo, level types.ValidationLevel)
 			bufferSize = 32768
 		}
 
-		srcHash, err := hashPartialFile(src.Path, bufferSize)
+		srcHash, err := hashPartialFile(src.Path, bufferSize, algo)
 		if err != nil {
 			logger.Debug("Hash error", "path", src.Path, "error", err)
 			return StatusError
 		}
-		tgtHash, err := hashPartialFile(tgt.Path, bufferSize)
+		tgtHash, err := hashPartialFile(tgt.Path, bufferSize, algo)
 		if err != nil {
 			logger.Debug("Hash error", "path", tgt.Path, "error", err)
 			return StatusError
@@ -168,12 +273,12 @@ func (s *Scanner) compareFiles(src, tgt *FileInfo, level types.ValidationLevel)
 	}
 
 	// Deep validation: full content hash
-	srcHash, err := hashFile(src.Path)
+	srcHash, err := hashFile(src.Path, algo)
 	if err != nil {
 		logger.Debug("Hash error", "path", src.Path, "error", err)
 		return StatusError
 	}
-	tgtHash, err := hashFile(tgt.Path)
+	tgtHash, err := hashFile(tgt.Path, algo)
 	if err != nil {
 		logger.Debug("Hash error", "path", tgt.Path, "error", err)
 		return StatusError
@@ -188,23 +293,54 @@ func (s *Scanner) compareFiles(src, tgt *FileInfo, level types.ValidationLevel)
 	return StatusMatch
 }
 
-// hashPartialFile reads and hashes only the first bufferSize bytes
-func hashPartialFile(path string, bufferSize int) (string, error) {
+// hashPartialFile hashes a bounded sample of path - its first bufferSize
+// bytes, its last bufferSize bytes, and its total size - using whichever
+// hash.Type algo names (ScannerOptions.HashAlgorithm, normally), so
+// Standard-level comparisons can trade SHA-256 for a cheaper
+// non-cryptographic hash (e.g. hash.XXHash) when collision resistance
+// doesn't matter for a quick content probe. Hashing head and tail
+// separately (rather than only the head, as an earlier version of this
+// function did) means two files sharing a common prefix but differing
+// near the end - or differing only in length past bufferSize - are no
+// longer reported as matching; folding in the size closes the remaining
+// gap for two files whose sampled head and tail both happen to collide.
+func hashPartialFile(path string, bufferSize int, algo hash.Type) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
 	defer f.Close()
 
-	h := sha256.New()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+
+	h := hash.New(algo)
 	buf := make([]byte, bufferSize)
 
 	n, err := io.ReadFull(f, buf)
 	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return "", err
 	}
-
 	h.Write(buf[:n])
+
+	if tailStart := size - int64(bufferSize); tailStart > 0 {
+		if _, err := f.Seek(tailStart, io.SeekStart); err != nil {
+			return "", err
+		}
+		tn, err := io.ReadFull(f, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		h.Write(buf[:tn])
+	}
+
+	var sizeBuf [8]byte
+	binary.BigEndian.PutUint64(sizeBuf[:], uint64(size))
+	h.Write(sizeBuf[:])
+
 	return string(h.Sum(nil)), nil
 }
 
@@ -215,14 +351,14 @@ func abs(n int64) int64 {
 	return n
 }
 
-func hashFile(path string) (string, error) {
+func hashFile(path string, algo hash.Type) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
 	defer f.Close()
 
-	h := sha256.New()
+	h := hash.New(algo)
 	if _, err := io.Copy(h, f); err != nil {
 		return "", err
 	}
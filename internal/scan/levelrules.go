@@ -0,0 +1,100 @@
+// internal/scan/levelrules.go
+package scan
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/types"
+)
+
+// LevelRule maps a glob pattern (doublestar-style: "**" matches any number
+// of path segments) to the validation level a matching path should use,
+// e.g. {"**/*.mp4", types.Quick} or {"docs/**", types.Deep}. Rules are
+// evaluated in order; the first match wins.
+type LevelRule struct {
+	Pattern string
+	Level   types.ValidationLevel
+}
+
+// compiledLevelRule is a LevelRule with its pattern precompiled, so
+// determineValidationLevel doesn't recompile a regexp per file.
+type compiledLevelRule struct {
+	re    *regexp.Regexp
+	level types.ValidationLevel
+}
+
+// levelMatcher is compiled once from ScannerOptions.LevelRules and cached
+// on Scanner, the same lazy-compile-once-then-reuse approach
+// validation.pathMatcher uses for CriticalPathRule - duplicated locally
+// rather than imported, since internal/validation already imports
+// internal/scan and a dependency the other way would cycle.
+type levelMatcher struct {
+	rules []compiledLevelRule
+}
+
+func newLevelMatcher(rules []LevelRule) *levelMatcher {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	m := &levelMatcher{rules: make([]compiledLevelRule, 0, len(rules))}
+	for _, rule := range rules {
+		m.rules = append(m.rules, compiledLevelRule{
+			re:    regexp.MustCompile(globToRegexp(rule.Pattern)),
+			level: rule.Level,
+		})
+	}
+	return m
+}
+
+// Level returns the level of the first rule matching relPath, and ok=false
+// if none do.
+func (m *levelMatcher) Level(relPath string) (types.ValidationLevel, bool) {
+	relPath = filepath.ToSlash(relPath)
+	for _, rule := range m.rules {
+		if rule.re.MatchString(relPath) {
+			return rule.level, true
+		}
+	}
+	return "", false
+}
+
+// globToRegexp translates a shell-style glob (with "**" matching any
+// number of path segments, including zero) into an anchored regexp
+// matching a slash-separated relative path.
+func globToRegexp(pattern string) string {
+	pattern = filepath.ToSlash(pattern)
+	var b strings.Builder
+	b.WriteByte('^')
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" matches zero or more whole segments, so "**/x"
+				// matches "x" at the root and "a/b/x", but not "foox".
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					b.WriteString("(?:.*/)?")
+					i++
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '.', '+', '(', ')', '|', '^', '$', '{', '}', '[', ']', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('$')
+	return b.String()
+}
@@ -0,0 +1,14 @@
+// internal/scan/normalize.go
+package scan
+
+import "golang.org/x/text/unicode/norm"
+
+// normalizeKey folds path to NFC so two Unicode-equivalent paths - an
+// NFD-decomposed name from a macOS source and the NFC-precomposed form of
+// the identical name on a Linux target - compare equal instead of being
+// treated as unrelated files, one "new" and the other "missing". Compare
+// applies this to every path it uses as a lookup key unless the caller set
+// DisableUnicodeNormalization.
+func normalizeKey(path string) string {
+	return norm.NFC.String(path)
+}
@@ -25,7 +25,7 @@
 //	    BufferSize:      32768,
 //	}
 //	scanner := scan.NewScanner(opts)
-//	progress, err := scanner.Scan("/path/to/source")
+//	progress, err := scanner.Scan(context.Background(), "/path/to/source")
 //
 // Performance Considerations:
 // - Groups files by directory to optimize for HDD access patterns
@@ -40,7 +40,9 @@
 package scan
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
@@ -48,17 +50,62 @@ import (
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/logger"
+	"github.com/jack-sneddon/backup-butler/internal/progress"
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
 	"github.com/jack-sneddon/backup-butler/internal/types"
 )
 
+// streamStatsInterval caps how often ScanStream publishes a non-final
+// ScanStats update, so a fast walk over small files doesn't flood the
+// stats channel.
+const streamStatsInterval = 200 * time.Millisecond
+
 type Scanner struct {
 	stats    map[string]*DirectoryStats
 	progress *Progress
 	rootPath string
 	opts     *ScannerOptions
 	mu       sync.Mutex // Protects stats map
+
+	reporter *progress.Reporter
+
+	// prevState is a previously saved snapshot loaded via LoadState, used
+	// to skip unchanged subtrees. state is the snapshot this run builds
+	// as it walks, persisted via SaveState for the next run to load.
+	prevState *ScanState
+	state     *ScanState
+
+	// levelMatcher is compiled once from opts.LevelRules at construction
+	// time, nil when no rules are configured.
+	levelMatcher *levelMatcher
+}
+
+// SetReporter attaches a shared progress.Reporter that countFiles and
+// scanFiles push updates into as they run, the same reporter a later
+// backup phase can keep pushing into - so one display shows scan totals
+// growing during count and then per-file transfer progress, instead of
+// Scanner and the backup phase each driving their own.
+func (s *Scanner) SetReporter(r *progress.Reporter) {
+	s.reporter = r
 }
 
+// SortOrder controls the order countFiles, scanFiles, and ScanStream visit
+// entries within each directory. The default, SortByName, makes scans
+// reproducible across runs (needed to verify versioned backups produce
+// identical file lists); SortBySize/SortByModTime let an operator bias early
+// progress toward the files that matter most to them - e.g. largest-first
+// for fast bytes-copied progress, or oldest-first for archival workflows.
+type SortOrder string
+
+const (
+	SortByName        SortOrder = "name" // default: lexical, ascending
+	SortByNameDesc    SortOrder = "name_desc"
+	SortBySize        SortOrder = "size"
+	SortBySizeDesc    SortOrder = "size_desc"
+	SortByModTime     SortOrder = "mtime"
+	SortByModTimeDesc SortOrder = "mtime_desc"
+)
+
 // ScannerOptions defines configuration options for Scanner
 type ScannerOptions struct {
 	ExcludePatterns  []string
@@ -67,6 +114,45 @@ type ScannerOptions struct {
 	BufferSize       int
 	Level            types.ValidationLevel
 	ValidationConfig *ValidationConfig
+	SortOrder        SortOrder
+
+	// SelectByName, if set, replaces ExcludePatterns/IncludeFolders as the
+	// cheap, pre-stat inclusion test. Compose GlobNameSelector,
+	// FolderNameSelector, GitignoreNameSelector, and AndNameSelectors to
+	// build one.
+	SelectByName NameSelector
+
+	// Select, if set, runs after a file is stat'ed and can consider size,
+	// mode, or modification time. Compose SizeThresholdSelector,
+	// ModTimeCutoffSelector, and AndSelectors to build one.
+	Select Selector
+
+	// DisableUnicodeNormalization turns off Compare's default NFC-folded
+	// path matching, so two paths that differ only in Unicode
+	// normalization form (e.g. an NFD-decomposed name written on macOS vs
+	// the NFC-precomposed form of the same name) are treated as genuinely
+	// different files rather than the same file under two encodings. Set
+	// this only when source and target really do contain such a pair and
+	// folding them together would wrongly merge them.
+	DisableUnicodeNormalization bool
+
+	// HashAlgorithm selects which hash.Type Standard/Deep-level
+	// compareFiles hashes both sides with. Defaults to hash.SHA256 when
+	// left as hash.None; a local-vs-local comparison can trade that for a
+	// cheaper non-cryptographic type like hash.XXHash when content forgery
+	// isn't a concern, the same negotiation storage.CheckHashes performs
+	// for a remote repository.Repository target.
+	HashAlgorithm hash.Type
+
+	// Concurrency bounds how many compareFiles calls Compare runs at once.
+	// Zero (the default) uses runtime.NumCPU().
+	Concurrency int
+
+	// LevelRules overrides Level on a per-path basis: the first rule whose
+	// Pattern matches a file's path (relative to the scan root, "**"-glob
+	// capable) sets that file's validation level instead of the default.
+	// A file matching no rule still uses Level.
+	LevelRules []LevelRule
 }
 
 func NewScanner(options *ScannerOptions) *Scanner {
@@ -90,7 +176,9 @@ func NewScanner(options *ScannerOptions) *Scanner {
 			Phase:     "initializing",
 			StartTime: time.Now(),
 		},
-		opts: options,
+		opts:         options,
+		state:        NewScanState(),
+		levelMatcher: newLevelMatcher(options.LevelRules),
 	}
 }
 
@@ -99,7 +187,7 @@ func (s *Scanner) GetProgress() *Progress {
 	return s.progress
 }
 
-func (s *Scanner) Scan(root string) (*Progress, error) {
+func (s *Scanner) Scan(ctx context.Context, root string) (*Progress, error) {
 	scanLogger := logger.WithGroup("scanner")
 
 	scanLogger.Info("Starting scan operation",
@@ -122,7 +210,7 @@ func (s *Scanner) Scan(root string) (*Progress, error) {
 
 	// First pass - count total files and size
 	scanLogger.Info("Starting file count phase")
-	if err := s.countFiles(root); err != nil {
+	if err := s.countFiles(ctx, root); err != nil {
 		return nil, err
 	}
 
@@ -131,16 +219,247 @@ func (s *Scanner) Scan(root string) (*Progress, error) {
 		"totalBytes", s.progress.TotalBytes,
 		"excludedFiles", s.progress.ExcludedFiles)
 
+	if s.reporter != nil {
+		s.reporter.ScanComplete()
+	}
+
 	// Second pass - detailed scan
 	scanLogger.Info("Starting detailed scan phase")
-	if err := s.scanFiles(root, 0); err != nil {
+	if err := s.scanFiles(ctx, root, 0); err != nil {
 		return nil, err
 	}
 
 	return s.progress, nil
 }
 
-func (s *Scanner) countFiles(root string) error {
+// ScanStats is a snapshot of running totals published periodically by
+// ScanStream as it walks the tree, so a caller can grow a progress bar's
+// denominator while discovery is still in flight instead of waiting for a
+// separate count pass to finish.
+type ScanStats struct {
+	TotalFiles int
+	TotalBytes int64
+	Done       bool
+}
+
+// ScanStream walks root exactly once, emitting each included file on the
+// returned FileInfo channel as soon as it's discovered and periodically
+// publishing running totals on the returned ScanStats channel. It replaces
+// the countFiles-then-scanFiles double walk Scan performs: a caller (such as
+// a worker pool) can start processing files immediately instead of waiting
+// for a full count pass, while still seeing an accurate final total once
+// both channels close.
+func (s *Scanner) ScanStream(ctx context.Context, root string) (<-chan *FileInfo, <-chan ScanStats, error) {
+	scanLogger := logger.WithGroup("scanner")
+	scanLogger.Info("Starting streaming scan operation",
+		"root", root,
+		"level", s.opts.Level)
+
+	s.rootPath = root
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(chan *FileInfo)
+	stats := make(chan ScanStats, 1)
+
+	go func() {
+		defer close(files)
+		defer close(stats)
+
+		var totalFiles int
+		var totalBytes int64
+		lastPublish := time.Now()
+
+		publish := func(force bool) {
+			if !force && time.Since(lastPublish) < streamStatsInterval {
+				return
+			}
+			if force {
+				// Drop any unread earlier snapshot so the final totals
+				// always fit in the channel's single buffer slot.
+				select {
+				case <-stats:
+				default:
+				}
+			}
+			select {
+			case stats <- ScanStats{TotalFiles: totalFiles, TotalBytes: totalBytes}:
+				lastPublish = time.Now()
+			default:
+			}
+		}
+
+		// walk visits dir's entries in s.opts.SortOrder, the same order
+		// countFiles/scanFiles use, so a streamed run and a two-pass run
+		// over the same tree produce identical file lists in identical
+		// order.
+		byName, selectFn := s.selectorsFor(absRoot)
+
+		var walk func(dir string) error
+		walk = func(dir string) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			entries, err := sortedReadDir(dir, s.opts.SortOrder)
+			if err != nil {
+				scanLogger.With("path", dir).Error("Access error", "error", err)
+				s.progress.AddError(NewScanError(dir, "access", err))
+				if s.reporter != nil {
+					s.reporter.ScannerError(dir, err)
+				}
+				return nil // Continue despite errors
+			}
+
+			for _, entry := range entries {
+				path := filepath.Join(dir, entry.Name())
+
+				if !byName(path) {
+					if entry.IsDir() {
+						s.progress.ExcludedDirs++
+					} else {
+						s.progress.ExcludedFiles++
+					}
+					continue
+				}
+
+				info, err := entry.Info()
+				if err != nil {
+					s.progress.AddError(NewScanError(path, "access", err))
+					if s.reporter != nil {
+						s.reporter.ScannerError(path, err)
+					}
+					continue
+				}
+
+				if info.IsDir() {
+					s.progress.ScannedDirs++
+					if err := walk(path); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if !selectFn(path, info) {
+					s.progress.ExcludedFiles++
+					continue
+				}
+
+				totalFiles++
+				totalBytes += info.Size()
+				publish(false)
+
+				if s.reporter != nil {
+					s.reporter.UpdateScanTotals(totalFiles, totalBytes)
+					s.reporter.StartFile(path)
+				}
+
+				fi := &FileInfo{
+					Path:    path,
+					Size:    info.Size(),
+					ModTime: info.ModTime().Unix(),
+					IsDir:   false,
+					Parent:  filepath.Dir(path),
+				}
+
+				select {
+				case files <- fi:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+
+				if s.reporter != nil {
+					s.reporter.CompleteFile("scanned", info.Size())
+				}
+			}
+
+			return nil
+		}
+
+		err := walk(root)
+		if err != nil {
+			s.progress.AddError(NewScanError(s.rootPath, "walk", err))
+		}
+
+		s.progress.TotalFiles = totalFiles
+		s.progress.TotalBytes = totalBytes
+		publish(true)
+		if s.reporter != nil {
+			s.reporter.ScanComplete()
+		}
+	}()
+
+	return files, stats, nil
+}
+
+// sortedReadDir reads dir's entries and orders them per order, so
+// countFiles, scanFiles, and ScanStream all walk a directory in the same
+// sequence regardless of which one is doing the walking.
+func sortedReadDir(dir string, order SortOrder) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	sortDirEntries(entries, order)
+	return entries, nil
+}
+
+// sortDirEntries sorts entries in place per order. Entries whose Info() call
+// fails sort as if zero-sized/zero-time rather than aborting the sort.
+func sortDirEntries(entries []os.DirEntry, order SortOrder) {
+	type pair struct {
+		entry os.DirEntry
+		info  os.FileInfo
+	}
+
+	pairs := make([]pair, len(entries))
+	for i, e := range entries {
+		info, _ := e.Info()
+		pairs[i] = pair{entry: e, info: info}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		switch order {
+		case SortBySize:
+			return sizeOrZero(pairs[i].info) < sizeOrZero(pairs[j].info)
+		case SortBySizeDesc:
+			return sizeOrZero(pairs[i].info) > sizeOrZero(pairs[j].info)
+		case SortByModTime:
+			return modTimeOrZero(pairs[i].info).Before(modTimeOrZero(pairs[j].info))
+		case SortByModTimeDesc:
+			return modTimeOrZero(pairs[i].info).After(modTimeOrZero(pairs[j].info))
+		case SortByNameDesc:
+			return pairs[i].entry.Name() > pairs[j].entry.Name()
+		default: // SortByName, ""
+			return pairs[i].entry.Name() < pairs[j].entry.Name()
+		}
+	})
+
+	for i := range pairs {
+		entries[i] = pairs[i].entry
+	}
+}
+
+func sizeOrZero(info os.FileInfo) int64 {
+	if info == nil {
+		return 0
+	}
+	return info.Size()
+}
+
+func modTimeOrZero(info os.FileInfo) time.Time {
+	if info == nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func (s *Scanner) countFiles(ctx context.Context, root string) error {
 	scanLogger := logger.WithGroup("scanner").With(
 		"root", root,
 		"level", s.opts.Level,
@@ -159,39 +478,88 @@ func (s *Scanner) countFiles(root string) error {
 		"excludePatterns", s.opts.ExcludePatterns,
 		"includeFolders", s.opts.IncludeFolders)
 
-	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+	_, _, err = s.countDir(ctx, root, absRoot, scanLogger)
+	return err
+}
+
+// countDir visits dir's entries in s.opts.SortOrder and recurses into
+// subdirectories, so the count pass and the later detailed scan pass walk
+// identical file lists in identical order. It returns the included file
+// count and total bytes for dir's whole subtree, which it also records into
+// s.state so a later run can skip dir via LoadState/SaveState if nothing
+// about it has changed.
+func (s *Scanner) countDir(ctx context.Context, dir, absRoot string, scanLogger *slog.Logger) (int, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	entries, err := sortedReadDir(dir, s.opts.SortOrder)
+	if err != nil {
+		scanLogger.With("path", dir).Error("Access error", "error", err)
+		s.progress.AddError(NewScanError(dir, "access", err))
+		if s.reporter != nil {
+			s.reporter.ScannerError(dir, err)
+		}
+		return 0, 0, nil // Continue despite errors
+	}
+
+	dirInfo, statErr := os.Stat(dir)
+	if statErr == nil {
+		if prev, ok := s.prevState.unchanged(dir, dirInfo.ModTime(), len(entries)); ok {
+			scanLogger.Debug("Subtree unchanged since last scan, skipping detailed count", "path", dir)
+			s.progress.TotalFiles += prev.FileCount
+			s.progress.TotalBytes += prev.TotalBytes
+			s.state.record(dir, dirInfo.ModTime(), len(entries), prev.FileCount, prev.TotalBytes)
+			if s.reporter != nil {
+				s.reporter.UpdateScanTotals(s.progress.TotalFiles, s.progress.TotalBytes)
+			}
+			return prev.FileCount, prev.TotalBytes, nil
+		}
+	}
+
+	byName, selectFn := s.selectorsFor(absRoot)
+
+	var fileCount int
+	var totalBytes int64
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return fileCount, totalBytes, err
+		}
+
+		path := filepath.Join(dir, entry.Name())
 		pathLogger := scanLogger.With("path", path)
+
+		if !byName(path) {
+			pathLogger.Debug("Excluding by name selector")
+			if entry.IsDir() {
+				s.progress.ExcludedDirs++
+			} else {
+				s.progress.ExcludedFiles++
+			}
+			continue
+		}
+
+		info, err := entry.Info()
 		if err != nil {
 			pathLogger.Error("Access error", "error", err)
 			s.progress.AddError(NewScanError(path, "access", err))
-			return nil // Continue despite errors
+			if s.reporter != nil {
+				s.reporter.ScannerError(path, err)
+			}
+			continue
 		}
 
 		if info.IsDir() {
-			// Skip directory pattern checks for root
-			if path != absRoot {
-				if !shouldIncludeFolder(path, s.opts.IncludeFolders) {
-					pathLogger.Debug("Excluding directory by folder list")
-					s.progress.ExcludedDirs++
-					return filepath.SkipDir
-				}
-				pathLogger.Debug("Processing directory")
-				// Get relative path for directory
-				relPath, err := filepath.Rel(absRoot, path)
-				if err != nil {
-					s.progress.AddError(NewScanError(path, "rel_path", err))
-					return nil
-				}
-				if matchesPattern(relPath, s.opts.ExcludePatterns) {
-					logger.Debug("Excluding directory by pattern",
-						"path", path,
-						"relPath", relPath)
-					s.progress.ExcludedDirs++
-					return filepath.SkipDir
-				}
-			}
+			pathLogger.Debug("Processing directory")
 			s.progress.ScannedDirs++
-			return nil
+			subFiles, subBytes, err := s.countDir(ctx, path, absRoot, scanLogger)
+			if err != nil {
+				return fileCount, totalBytes, err
+			}
+			fileCount += subFiles
+			totalBytes += subBytes
+			continue
 		}
 
 		// Handle files
@@ -199,46 +567,34 @@ func (s *Scanner) countFiles(root string) error {
 			"size", info.Size(),
 			"modTime", info.ModTime(),
 		)
-		if len(s.opts.ExcludePatterns) > 0 {
-			relPath, err := filepath.Rel(absRoot, path)
-			if err != nil {
-				s.progress.AddError(NewScanError(path, "rel_path", err))
-				return nil
-			}
-			/*
-				s.logger.Debug("Checking file against patterns",
-					"relPath", relPath,
-					"patterns", s.opts.ExcludePatterns)
-			*/
-
-			if shouldExclude := matchesPattern(relPath, s.opts.ExcludePatterns); shouldExclude {
-				/*
-					s.logger.Debug("Excluding file by pattern",
-						"path", path,
-						"relPath", relPath,
-						"patterns", s.opts.ExcludePatterns)
-				*/
-				s.progress.ExcludedFiles++
-				return nil
-			}
+		if !selectFn(path, info) {
+			pathLogger.Debug("Excluding by content selector")
+			s.progress.ExcludedFiles++
+			continue
 		}
 
 		// Include the file in totals
+		fileCount++
+		totalBytes += info.Size()
 		s.progress.TotalFiles++
 		s.progress.TotalBytes += info.Size()
-		/*
-			s.logger.Debug("Including file",
-				"path", path,
-				"size", info.Size(),
-				"totalFiles", s.progress.TotalFiles,
-				"totalBytes", s.progress.TotalBytes)
-		*/
+		if s.reporter != nil {
+			s.reporter.UpdateScanTotals(s.progress.TotalFiles, s.progress.TotalBytes)
+		}
+	}
 
-		return nil
-	})
+	if statErr == nil {
+		s.state.record(dir, dirInfo.ModTime(), len(entries), fileCount, totalBytes)
+	}
+
+	return fileCount, totalBytes, nil
 }
 
-func (s *Scanner) scanFiles(root string, depth int) error {
+func (s *Scanner) scanFiles(ctx context.Context, root string, depth int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	scanLogger := logger.WithGroup("scanner").With("path", root)
 
 	// Convert root to absolute path
@@ -253,13 +609,22 @@ func (s *Scanner) scanFiles(root string, depth int) error {
 		return nil
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := sortedReadDir(root, s.opts.SortOrder)
 	if err != nil {
 		scanLogger.Error("Failed to read directory", "error", err)
 		s.progress.AddError(NewScanError(root, "read_dir", err))
 		return nil
 	}
 
+	if dirInfo, statErr := os.Stat(root); statErr == nil {
+		if prev, ok := s.prevState.unchanged(root, dirInfo.ModTime(), len(entries)); ok {
+			scanLogger.Debug("Subtree unchanged since last scan, skipping detailed scan", "path", root)
+			s.progress.ScannedFiles += prev.FileCount
+			s.progress.ProcessedBytes += prev.TotalBytes
+			return nil
+		}
+	}
+
 	s.progress.CurrentDir = root
 	s.progress.CurrentDirStart = time.Now()
 
@@ -286,8 +651,20 @@ func (s *Scanner) scanFiles(root string, depth int) error {
 	s.progress.CurrentDirDone = 0
 	s.progress.CurrentDirBytes = 0
 
+	byName, selectFn := s.selectorsFor(absRoot)
+
 	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		path := filepath.Join(root, entry.Name())
+
+		if path != absRoot && !byName(path) {
+			scanLogger.Debug("Excluding by name selector", "path", path)
+			continue
+		}
+
 		info, err := entry.Info()
 		if err != nil {
 			scanLogger.Error("Failed to get file info",
@@ -298,44 +675,23 @@ func (s *Scanner) scanFiles(root string, depth int) error {
 		}
 
 		if info.IsDir() {
-			// Skip directory pattern checks for root
-			if path != absRoot {
-				if !shouldIncludeFolder(path, s.opts.IncludeFolders) {
-					// Add logging but keep existing logic
-					scanLogger.Debug("Excluding directory",
-						"path", path,
-						"reason", "folder list")
-					continue
+			if err := s.scanFiles(ctx, path, depth+1); err != nil {
+				if ctx.Err() != nil {
+					return err
 				}
-				// Get relative path for directory
-				relPath, err := filepath.Rel(absRoot, path)
-				if err != nil {
-					s.progress.AddError(NewScanError(path, "rel_path", err))
-					continue
-				}
-				if matchesPattern(relPath, s.opts.ExcludePatterns) {
-					scanLogger.Debug("Excluding directory",
-						"path", path,
-						"reason", "pattern match")
-					continue
-				}
-			}
-			if err := s.scanFiles(path, depth+1); err != nil {
 				s.progress.AddError(err)
 			}
 			continue
 		}
 
 		// File processing with progress tracking
-		// Get relative path for file
-		relPath, err := filepath.Rel(absRoot, path)
-		if err != nil {
-			s.progress.AddError(NewScanError(path, "rel_path", err))
+		if !selectFn(path, info) {
+			scanLogger.Debug("Excluding by content selector", "path", path)
 			continue
 		}
 
-		if matchesPattern(relPath, s.opts.ExcludePatterns) {
-			continue
+		if s.reporter != nil {
+			s.reporter.StartFile(path)
 		}
 
 		s.progress.ScannedFiles++
@@ -345,6 +701,10 @@ func (s *Scanner) scanFiles(root string, depth int) error {
 		s.progress.CurrentDirDone++
 		s.progress.CurrentDirBytes += info.Size()
 
+		if s.reporter != nil {
+			s.reporter.CompleteFile("scanned", info.Size())
+		}
+
 		logger.Debug("File processed",
 			"directory", root,
 			"progress", fmt.Sprintf("%d/%d", s.progress.CurrentDirDone, s.progress.CurrentDirFiles),
@@ -0,0 +1,145 @@
+// internal/scan/selector.go
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NameSelector decides whether path is worth stat'ing at all, based on the
+// path alone. It runs before os.Lstat so an excluded directory subtree never
+// costs a single stat call, and returns true to keep path, false to skip it.
+type NameSelector func(path string) bool
+
+// Selector makes the final inclusion decision for a file once its
+// os.FileInfo is available, so it can consider size, mode, or modification
+// time that the name alone can't reveal. It returns true to keep the file.
+type Selector func(path string, fi os.FileInfo) bool
+
+// AndNameSelectors combines selectors so a path is kept only if every one of
+// them keeps it. A nil or empty list keeps everything.
+func AndNameSelectors(selectors ...NameSelector) NameSelector {
+	return func(path string) bool {
+		for _, sel := range selectors {
+			if sel != nil && !sel(path) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AndSelectors combines selectors so a file is kept only if every one of
+// them keeps it. A nil or empty list keeps everything.
+func AndSelectors(selectors ...Selector) Selector {
+	return func(path string, fi os.FileInfo) bool {
+		for _, sel := range selectors {
+			if sel != nil && !sel(path, fi) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// GlobNameSelector excludes paths matching any of the given filepath.Match
+// glob patterns, evaluated against path as-is. The Scanner passes the path
+// it joined from the directory being walked, which starts with the scan
+// root rather than being relative to it.
+func GlobNameSelector(patterns []string) NameSelector {
+	return func(path string) bool {
+		return !matchesPattern(path, patterns)
+	}
+}
+
+// FolderNameSelector keeps only paths under one of the named folders,
+// matching on any path segment. An empty folders list keeps everything.
+func FolderNameSelector(folders []string) NameSelector {
+	return func(path string) bool {
+		return shouldIncludeFolder(path, folders)
+	}
+}
+
+// GitignoreNameSelector excludes paths matching any of the given
+// .gitignore-style rules: a pattern containing "/" matches the full path as
+// passed in (see GlobNameSelector), while a pattern with no "/" matches against any path
+// segment, the same way a bare .gitignore entry matches a file or directory
+// at any depth. This is a practical subset, not a full gitignore
+// implementation (no "!" negation or "**" double-star semantics).
+func GitignoreNameSelector(patterns []string) NameSelector {
+	return func(path string) bool {
+		path = filepath.ToSlash(path)
+		for _, pattern := range patterns {
+			pattern = strings.TrimSuffix(pattern, "/")
+			if pattern == "" {
+				continue
+			}
+			if strings.Contains(pattern, "/") {
+				if matched, _ := filepath.Match(pattern, path); matched {
+					return false
+				}
+				continue
+			}
+			for _, segment := range strings.Split(path, "/") {
+				if matched, _ := filepath.Match(pattern, segment); matched {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// SizeThresholdSelector excludes files larger than maxBytes, letting an
+// operator skip huge scratch files (--exclude-larger-than) without reading
+// their contents. maxBytes <= 0 disables the check.
+func SizeThresholdSelector(maxBytes int64) Selector {
+	return func(path string, fi os.FileInfo) bool {
+		if maxBytes <= 0 {
+			return true
+		}
+		return fi.Size() <= maxBytes
+	}
+}
+
+// ModTimeCutoffSelector excludes files last modified before cutoff, letting
+// an operator skip stale caches (--exclude-older-than). A zero cutoff
+// disables the check.
+func ModTimeCutoffSelector(cutoff time.Time) Selector {
+	return func(path string, fi os.FileInfo) bool {
+		if cutoff.IsZero() {
+			return true
+		}
+		return !fi.ModTime().Before(cutoff)
+	}
+}
+
+// selectorsFor returns the NameSelector/Selector pair a scan pass should use
+// for a run rooted at absRoot: the ScannerOptions.SelectByName/Select hooks
+// if the caller set them, otherwise selectors built from the legacy
+// ExcludePatterns/IncludeFolders fields so existing configs keep working
+// unchanged.
+func (s *Scanner) selectorsFor(absRoot string) (NameSelector, Selector) {
+	byName := s.opts.SelectByName
+	if byName == nil {
+		byName = AndNameSelectors(
+			FolderNameSelector(s.opts.IncludeFolders),
+			func(path string) bool {
+				relPath, err := filepath.Rel(absRoot, path)
+				if err != nil {
+					return true
+				}
+				return !matchesPattern(relPath, s.opts.ExcludePatterns)
+			},
+		)
+	}
+
+	selectFn := s.opts.Select
+	if selectFn == nil {
+		selectFn = func(path string, fi os.FileInfo) bool { return true }
+	}
+
+	return byName, selectFn
+}
@@ -0,0 +1,123 @@
+// internal/scan/state.go
+package scan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirState captures what a scan observed for a single directory: cheap
+// enough (mtime + entry count, plus the totals that fell out of a full
+// walk) to let a later run decide whether the subtree changed without
+// re-reading every file in it.
+type DirState struct {
+	ModTime    int64 `json:"mod_time"`
+	EntryCount int   `json:"entry_count"`
+	FileCount  int   `json:"file_count"`
+	TotalBytes int64 `json:"total_bytes"`
+}
+
+// ScanState is a snapshot of DirState for every directory a scan visited,
+// persisted to disk so a later run can skip unchanged subtrees instead of
+// re-walking them. SnapshotID lets a caller (such as a VersionManagerPort
+// implementation) record which scan-state snapshot a backup version was
+// produced from.
+type ScanState struct {
+	SnapshotID string              `json:"snapshot_id"`
+	Dirs       map[string]DirState `json:"dirs"`
+}
+
+// NewScanState returns an empty ScanState ready to be populated by a scan.
+func NewScanState() *ScanState {
+	return &ScanState{Dirs: make(map[string]DirState)}
+}
+
+// unchanged reports whether dir's previously recorded mtime and entry count
+// still match, meaning the subtree can be trusted without a detailed walk,
+// and returns the recorded state so the caller can reuse its totals.
+func (st *ScanState) unchanged(dir string, modTime time.Time, entryCount int) (DirState, bool) {
+	if st == nil {
+		return DirState{}, false
+	}
+	prev, ok := st.Dirs[dir]
+	if !ok {
+		return DirState{}, false
+	}
+	return prev, prev.ModTime == modTime.Unix() && prev.EntryCount == entryCount
+}
+
+// record stores dir's observed state for the next run to diff against.
+func (st *ScanState) record(dir string, modTime time.Time, entryCount, fileCount int, totalBytes int64) {
+	st.Dirs[dir] = DirState{
+		ModTime:    modTime.Unix(),
+		EntryCount: entryCount,
+		FileCount:  fileCount,
+		TotalBytes: totalBytes,
+	}
+}
+
+// LoadScanState reads a previously saved ScanState from path. A missing
+// file is not an error - it just means there's no prior snapshot to diff
+// against, so the caller falls back to a full scan.
+func LoadScanState(path string) (*ScanState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewScanState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read scan state %s: %w", path, err)
+	}
+
+	st := NewScanState()
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parse scan state %s: %w", path, err)
+	}
+	if st.Dirs == nil {
+		st.Dirs = make(map[string]DirState)
+	}
+	return st, nil
+}
+
+// Save writes st to path as indented JSON, creating parent directories as
+// needed.
+func (st *ScanState) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create scan state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode scan state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write scan state %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadState loads a previously saved ScanState from path so the next Scan
+// (its countFiles and scanFiles passes) can skip subtrees that haven't
+// changed since; ScanStream does not consult it. A missing file is not an
+// error: Scan simply performs a full walk.
+func (s *Scanner) LoadState(path string) error {
+	st, err := LoadScanState(path)
+	if err != nil {
+		return err
+	}
+	s.prevState = st
+	return nil
+}
+
+// SaveState persists the ScanState built by the most recent scan to path,
+// so the next run can diff against it.
+func (s *Scanner) SaveState(path string) error {
+	if s.state == nil {
+		s.state = NewScanState()
+	}
+	return s.state.Save(path)
+}
@@ -0,0 +1,42 @@
+// Package backend selects a repository.Repository implementation for a
+// configured target string, the way restic/rclone dispatch on a URL scheme
+// to decide which backend a repository location actually names.
+package backend
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository/local"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository/s3"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository/sftp"
+)
+
+// Open selects and constructs a Repository for target: a bare path or a
+// file:// URL backs onto local disk, sftp://user@host/path onto an SFTP
+// server, and s3://[credentials@]endpoint/bucket/prefix onto a bucket at an
+// S3-compatible endpoint. bufferSize
+// sizes S3 multipart upload parts.
+func Open(target string, bufferSize int) (repository.Repository, error) {
+	if !strings.Contains(target, "://") {
+		return local.New(target), nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %q: %w", target, err)
+	}
+
+	switch u.Scheme {
+	case "", "file":
+		return local.New(u.Path), nil
+	case "sftp":
+		return sftp.New(u)
+	case "s3", "s3-insecure":
+		return s3.New(u, bufferSize)
+	default:
+		return nil, fmt.Errorf("unsupported target scheme %q", u.Scheme)
+	}
+}
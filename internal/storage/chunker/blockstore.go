@@ -0,0 +1,77 @@
+// internal/storage/chunker/blockstore.go
+package chunker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// BlockStore tracks which chunk digests have already been seen somewhere in
+// the target tree, so ChunkedValidator (and, eventually, an incremental
+// copy path) can recognize a chunk that's merely been duplicated or moved -
+// a re-imported photo library, a renamed file - instead of treating every
+// occurrence as new content. It's a single shared index rather than
+// FileIndex's per-file sidecars, since the question it answers is "has this
+// digest been seen anywhere in the tree", not "what are this file's chunks".
+type BlockStore struct {
+	mu    sync.Mutex
+	path  string
+	known map[string]int // digest -> number of times it's been recorded
+}
+
+// OpenBlockStore loads (or initializes) the shared block index at
+// indexDir/blocks.json.
+func OpenBlockStore(indexDir string) (*BlockStore, error) {
+	path := filepath.Join(indexDir, "blocks.json")
+	known := make(map[string]int)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &known); err != nil {
+			return nil, fmt.Errorf("failed to parse block store: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read block store: %w", err)
+	}
+
+	return &BlockStore{path: path, known: known}, nil
+}
+
+// Seen reports whether digest has already been recorded, i.e. whether this
+// chunk's content already exists somewhere else in the tree.
+func (s *BlockStore) Seen(digest string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.known[digest] > 0
+}
+
+// Record marks digest as present, incrementing its reference count.
+func (s *BlockStore) Record(digest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.known[digest]++
+}
+
+// Save persists the index to disk, creating indexDir if needed.
+func (s *BlockStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create block store directory: %w", err)
+	}
+
+	data, err := json.Marshal(s.known)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block store: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write block store: %w", err)
+	}
+
+	return nil
+}
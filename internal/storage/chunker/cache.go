@@ -0,0 +1,90 @@
+// internal/storage/chunker/cache.go
+package chunker
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is an in-memory, LRU-bounded store of chunk digest -> chunk bytes,
+// shared across backup runs the way BuildKit's fscache lets build steps
+// reuse content fetched by earlier steps. It's intentionally process-local:
+// chunk bytes live as long as the process does, and eviction just means the
+// next lookup falls back to reading the chunk off disk again.
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	order    *list.List // most-recently-used at the front
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	digest string
+	data   []byte
+}
+
+// NewCache returns a Cache bounded to maxBytes of chunk content. A
+// non-positive maxBytes disables the cache (every lookup misses).
+func NewCache(maxBytes int64) *Cache {
+	return &Cache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached bytes for digest, if present.
+func (c *Cache) Get(digest string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[digest]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).data, true
+}
+
+// Put stores data under digest, evicting the least-recently-used entries
+// until the cache fits within maxBytes.
+func (c *Cache) Put(digest string, data []byte) {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[digest]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&cacheEntry{digest: digest, data: data})
+	c.entries[digest] = elem
+	c.curBytes += int64(len(data))
+
+	for c.curBytes > c.maxBytes {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.evict(oldest)
+	}
+}
+
+func (c *Cache) evict(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	c.order.Remove(elem)
+	delete(c.entries, entry.digest)
+	c.curBytes -= int64(len(entry.data))
+}
+
+// Len reports the number of chunks currently cached.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,130 @@
+// Package chunker implements content-defined chunking so large files can be
+// diffed and copied chunk-by-chunk instead of whole-file, the way BuildKit's
+// content store and restic's repack both avoid re-transferring unchanged
+// regions of a large blob.
+//
+// Chunk boundaries are picked with a FastCDC-style gear hash: a rolling
+// hash over a byte window is compared against a mask, and a boundary is cut
+// whenever the low bits of the hash are zero, subject to Min/Max/Average
+// size constraints. Because the boundary only depends on local content, an
+// insertion or deletion in the middle of a file shifts at most the chunks
+// touching the edit - everything else re-chunks identically.
+package chunker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+const (
+	DefaultMinSize     = 512 * 1024      // 512KB
+	DefaultAverageSize = 1024 * 1024     // 1MB
+	DefaultMaxSize     = 4 * 1024 * 1024 // 4MB
+)
+
+// Chunk describes one content-defined region of a file.
+type Chunk struct {
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Digest string `json:"digest"` // hex SHA-256 of the chunk's bytes
+}
+
+// Chunker splits a stream into content-defined chunks.
+type Chunker struct {
+	MinSize     int
+	AverageSize int
+	MaxSize     int
+	mask        uint64
+}
+
+// New returns a Chunker using BuildKit/FastCDC-typical defaults (512KB min,
+// 1MB average, 4MB max).
+func New() *Chunker {
+	return NewWithSizes(DefaultMinSize, DefaultAverageSize, DefaultMaxSize)
+}
+
+// NewWithSizes returns a Chunker with custom size bounds. The cut mask is
+// derived from averageSize so that, on uniformly random data, a boundary is
+// expected roughly every averageSize bytes.
+func NewWithSizes(minSize, averageSize, maxSize int) *Chunker {
+	bits := 0
+	for n := averageSize; n > 1; n >>= 1 {
+		bits++
+	}
+	return &Chunker{
+		MinSize:     minSize,
+		AverageSize: averageSize,
+		MaxSize:     maxSize,
+		mask:        (1 << uint(bits)) - 1,
+	}
+}
+
+// Split reads r to EOF and returns the content-defined chunks, in order.
+func (c *Chunker) Split(r io.Reader) ([]Chunk, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.SplitBytes(data), nil
+}
+
+// SplitBytes is the in-memory equivalent of Split, useful when the caller
+// already has the file mapped or buffered.
+func (c *Chunker) SplitBytes(data []byte) []Chunk {
+	var chunks []Chunk
+	start := 0
+
+	for start < len(data) {
+		end := c.findCutPoint(data[start:])
+		chunkData := data[start : start+end]
+
+		digest := sha256.Sum256(chunkData)
+		chunks = append(chunks, Chunk{
+			Offset: int64(start),
+			Size:   int64(len(chunkData)),
+			Digest: hex.EncodeToString(digest[:]),
+		})
+
+		start += end
+	}
+
+	return chunks
+}
+
+// findCutPoint returns the length of the next chunk cut from the start of
+// data, honoring MinSize/MaxSize and the gear-hash boundary condition.
+func (c *Chunker) findCutPoint(data []byte) int {
+	if len(data) <= c.MinSize {
+		return len(data)
+	}
+
+	limit := len(data)
+	if limit > c.MaxSize {
+		limit = c.MaxSize
+	}
+
+	var hash uint64
+	for i := c.MinSize; i < limit; i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&c.mask == 0 {
+			return i + 1
+		}
+	}
+
+	return limit
+}
+
+// gearTable holds fixed pseudo-random 64-bit values, one per byte value,
+// used to mix each byte into the rolling hash (the "gear hash" from the
+// FastCDC paper). The constants are arbitrary but fixed, so chunking is
+// deterministic across runs and machines.
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+	seed := uint64(0x9E3779B97F4A7C15)
+	for i := range table {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		table[i] = seed
+	}
+	return table
+}()
@@ -0,0 +1,65 @@
+// internal/storage/chunker/index.go
+package chunker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileIndex is the sidecar chunk list for one backed-up file, stored next
+// to the version manifest so a later run can diff against it without
+// re-chunking the destination copy.
+type FileIndex struct {
+	Path     string  `json:"path"`
+	Size     int64   `json:"size"`
+	Checksum string  `json:"checksum"` // whole-file SHA-256, for a fast equality short-circuit
+	Chunks   []Chunk `json:"chunks"`
+}
+
+// IndexPath returns the sidecar index path for relPath under indexDir,
+// mirroring relPath's directory structure with a ".chunks.json" suffix.
+func IndexPath(indexDir, relPath string) string {
+	return filepath.Join(indexDir, relPath+".chunks.json")
+}
+
+// SaveIndex writes idx to its sidecar path, creating parent directories as
+// needed.
+func SaveIndex(indexDir, relPath string, idx *FileIndex) error {
+	path := IndexPath(indexDir, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create chunk index directory: %w", err)
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chunk index: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write chunk index: %w", err)
+	}
+
+	return nil
+}
+
+// LoadIndex reads the sidecar index for relPath, returning (nil, nil) if no
+// index has been recorded yet - a plain cache miss, not an error.
+func LoadIndex(indexDir, relPath string) (*FileIndex, error) {
+	path := IndexPath(indexDir, relPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read chunk index: %w", err)
+	}
+
+	var idx FileIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse chunk index: %w", err)
+	}
+
+	return &idx, nil
+}
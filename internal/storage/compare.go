@@ -2,12 +2,16 @@
 package storage
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
 )
 
 const (
@@ -20,10 +24,57 @@ type CompareStrategy interface {
 }
 
 type Metadata struct {
-	Size         int64
-	ModTime      time.Time
-	QuickHash    string
-	FullChecksum string
+	Size    int64
+	ModTime time.Time
+
+	// QuickHash and FullChecksum are keyed by the hash.Type CheckHashes
+	// actually negotiated for that comparison, rather than a single
+	// hardcoded SHA-256 string, so a destination that can only produce one
+	// particular type (e.g. a future S3 backend's MD5 ETag) is compared
+	// using that type instead of forcing a re-hash.
+	QuickHash    map[hash.Type]string
+	FullChecksum map[hash.Type]string
+}
+
+// ErrNoCommonHash is returned by CheckHashes when src and dst share no hash
+// type in common (one of them may report hash.None). Callers should fall
+// back to a size+mtime comparison and warn that the transfer is unverified.
+var ErrNoCommonHash = errors.New("no common hash type between source and destination")
+
+// CheckHashes intersects src and dst's supported hash.Set, picks the
+// cheapest type they have in common, and compares a single digest of that
+// type from each side - so a single read of each file produces a verified
+// answer without assuming SHA-256 is available (or the cheapest option) on
+// both ends.
+func CheckHashes(ctx context.Context, src, dst hash.Hasher) (equal bool, ht hash.Type, err error) {
+	equal, ht, _, _, err = checkHashesWithSums(ctx, src, dst)
+	return equal, ht, err
+}
+
+// checkHashesWithSums is CheckHashes plus the two digests it computed, for
+// callers (like FullChecksumCompare) that want to cache the negotiated
+// digest without hashing the file a second time.
+func checkHashesWithSums(ctx context.Context, src, dst hash.Hasher) (equal bool, ht hash.Type, srcSum, dstSum string, err error) {
+	picked, ok := hash.Pick(src.Hashes(), dst.Hashes())
+	if !ok {
+		return false, hash.None, "", "", ErrNoCommonHash
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, picked, "", "", err
+	}
+
+	srcSum, err = src.Sum(picked)
+	if err != nil {
+		return false, picked, "", "", fmt.Errorf("hash source: %w", err)
+	}
+
+	dstSum, err = dst.Sum(picked)
+	if err != nil {
+		return false, picked, srcSum, "", fmt.Errorf("hash destination: %w", err)
+	}
+
+	return srcSum == dstSum, picked, srcSum, dstSum, nil
 }
 
 // CompareResult now includes which strategy was used
@@ -67,27 +118,24 @@ func (m *MetadataCompare) Priority() int {
 	return 1
 }
 
-// QuickHashCompare calculates hash of first 64KB
+// QuickHashCompare negotiates a hash type over the first 64KB of each file.
 type QuickHashCompare struct{}
 
 func (q *QuickHashCompare) Compare(src, dst string, meta *Metadata) (CompareResult, error) {
-	// Calculate source quick hash if not already done
-	if meta.QuickHash == "" {
-		srcHash, err := calculateQuickHash(src)
-		if err != nil {
-			return CompareResult{NeedsCopy: true, Reason: "try next strategy", Strategy: "quickhash"}, err
-		}
-		meta.QuickHash = srcHash
-	}
-
-	// Calculate destination quick hash
-	dstHash, err := calculateQuickHash(dst)
+	equal, ht, srcSum, _, err := checkHashesWithSums(context.Background(),
+		prefixHasher{FileHasher: FileHasher{Path: src}, limit: QuickHashSize},
+		prefixHasher{FileHasher: FileHasher{Path: dst}, limit: QuickHashSize},
+	)
 	if err != nil {
 		return CompareResult{NeedsCopy: true, Reason: "try next strategy", Strategy: "quickhash"}, err
 	}
 
-	// If quick hashes match, files are very likely identical
-	if meta.QuickHash == dstHash {
+	if meta.QuickHash == nil {
+		meta.QuickHash = make(map[hash.Type]string)
+	}
+	meta.QuickHash[ht] = srcSum
+
+	if equal {
 		return CompareResult{NeedsCopy: false, Reason: "quick hash match", Strategy: "quickhash"}, nil
 	}
 
@@ -103,23 +151,21 @@ func (q *QuickHashCompare) Priority() int {
 type FullChecksumCompare struct{}
 
 func (f *FullChecksumCompare) Compare(src, dst string, meta *Metadata) (CompareResult, error) {
-	// Calculate source checksum if not already done
-	if meta.FullChecksum == "" {
-		srcChecksum, err := calculateFullChecksum(src)
-		if err != nil {
-			return CompareResult{NeedsCopy: true, Reason: "checksum failed", Strategy: "checksum"}, err
-		}
-		meta.FullChecksum = srcChecksum
-	}
-
-	// Calculate destination checksum
-	dstChecksum, err := calculateFullChecksum(dst)
+	equal, ht, srcSum, _, err := checkHashesWithSums(context.Background(),
+		FileHasher{Path: src},
+		FileHasher{Path: dst},
+	)
 	if err != nil {
 		return CompareResult{NeedsCopy: true, Reason: "checksum failed", Strategy: "checksum"}, err
 	}
 
+	if meta.FullChecksum == nil {
+		meta.FullChecksum = make(map[hash.Type]string)
+	}
+	meta.FullChecksum[ht] = srcSum
+
 	// Checksums match = files are identical
-	if meta.FullChecksum == dstChecksum {
+	if equal {
 		return CompareResult{NeedsCopy: false, Reason: "checksum match", Strategy: "checksum"}, nil
 	}
 
@@ -3,29 +3,132 @@ package storage
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/storage/chunker"
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository"
 )
 
+// chunkedCopyThreshold is the minimum source size before Copy bothers
+// content-defined chunking a pre-existing destination file; below this it's
+// cheaper to just rewrite the whole thing.
+const chunkedCopyThreshold = 4 * chunker.DefaultMaxSize
+
+// defaultParallelCopyThreshold is the minimum source size before Copy
+// splits a fresh (no pre-existing destination) copy into fixed-size blocks
+// written concurrently, rather than one serial copyFull pass.
+const defaultParallelCopyThreshold = 64 * 1024 * 1024 // 64MiB
+
+// defaultParallelBlockSize is the fixed block size copyParallel splits the
+// source into.
+const defaultParallelBlockSize = 4 * 1024 * 1024 // 4MiB
+
 // Copier handles file copy operations with verification
 type Copier struct {
 	manager    *Manager
 	bufferSize int
+	chunkCache *chunker.Cache
+
+	maxThreads            int
+	parallelCopyThreshold int64
+	parallelBlockSize     int64
+
+	// OnBlockCopied, if set, is called after each block of a parallel copy
+	// is written, so a caller can drive a progress.Tracker's ETA. It may be
+	// called concurrently from multiple worker goroutines.
+	OnBlockCopied func(bytesWritten int64)
 }
 
-// NewCopier creates a new copier instance
-func NewCopier(manager *Manager, bufferSize int) *Copier {
+// NewCopier creates a new copier instance. maxThreads bounds how many
+// blocks a parallel copy (see copyParallel) writes concurrently; callers
+// typically pass cfg.Concurrency so one setting governs both file-level
+// and block-level parallelism.
+func NewCopier(manager *Manager, bufferSize int, maxThreads int) *Copier {
+	if maxThreads < 1 {
+		maxThreads = 1
+	}
 	return &Copier{
-		manager:    manager,
-		bufferSize: bufferSize,
+		manager:               manager,
+		bufferSize:            bufferSize,
+		chunkCache:            chunker.NewCache(0), // disabled until SetChunkCacheSize is called
+		maxThreads:            maxThreads,
+		parallelCopyThreshold: defaultParallelCopyThreshold,
+		parallelBlockSize:     defaultParallelBlockSize,
 	}
 }
 
-// Copy performs the file copy operation with verification
+// SetChunkCacheSize (re)sizes the shared chunk cache used when reusing
+// destination chunks on Copy; a non-positive size disables the cache, so
+// only chunks found elsewhere in dst itself are counted as reused.
+func (c *Copier) SetChunkCacheSize(maxBytes int64) {
+	c.chunkCache = chunker.NewCache(maxBytes)
+}
+
+// SetParallelCopyThreshold overrides the size at which Copy switches a
+// fresh copy from copyFull to copyParallel. A non-positive value disables
+// parallel copy entirely.
+func (c *Copier) SetParallelCopyThreshold(bytes int64) {
+	c.parallelCopyThreshold = bytes
+}
+
+// bufferSizeFor returns the read/write buffer size a copy into ctx should
+// use: ctx's config.Info.BufferSize when one's been scoped in (e.g. a
+// per-folder override via config.AddConfig), otherwise the Copier's own
+// constructor value.
+func (c *Copier) bufferSizeFor(ctx context.Context) int {
+	if ci := config.FromContext(ctx); ci.BufferSize > 0 {
+		return ci.BufferSize
+	}
+	return c.bufferSize
+}
+
+// throttle blocks just long enough to keep written bytes since start
+// within ctx's config.Info.BandwidthLimit, a coarse token bucket that
+// needs no cross-goroutine coordination - good enough to keep one copy
+// from saturating a constrained link, even though it doesn't share a
+// budget across concurrent copies the way a true global limiter would.
+func throttle(ctx context.Context, written int64, start time.Time) {
+	ci := config.FromContext(ctx)
+	if ci.BandwidthLimit <= 0 {
+		return
+	}
+	allowed := time.Duration(float64(written) / float64(ci.BandwidthLimit) * float64(time.Second))
+	if elapsed := time.Since(start); allowed > elapsed {
+		time.Sleep(allowed - elapsed)
+	}
+}
+
+// Copy performs the file copy operation with verification. For large files
+// where a destination already exists, it first tries copyChunked, which
+// only rewrites the regions that actually changed; copyChunked falls back
+// to a full rewrite only when the existing destination can't be read. For
+// large files with no existing destination, it uses copyParallel instead
+// of a single serial pass. Everything else falls back to copyFull.
 func (c *Copier) Copy(ctx context.Context, src, dst string) (CopyResult, error) {
+	info, statErr := os.Stat(src)
+	if statErr == nil && info.Size() >= chunkedCopyThreshold {
+		if _, err := os.Stat(dst); err == nil {
+			return c.copyChunked(ctx, src, dst, info.Size())
+		}
+	}
+	if statErr == nil && c.parallelCopyThreshold > 0 && info.Size() >= c.parallelCopyThreshold {
+		return c.copyParallel(ctx, src, dst, info.Size())
+	}
+	return c.copyFull(ctx, src, dst)
+}
+
+// copyFull performs a whole-file copy, used for small files and for any
+// file that doesn't already have a destination copy to diff against.
+func (c *Copier) copyFull(ctx context.Context, src, dst string) (CopyResult, error) {
 	startTime := time.Now()
 
 	// Create destination directory if needed
@@ -48,7 +151,7 @@ func (c *Copier) Copy(ctx context.Context, src, dst string) (CopyResult, error)
 	defer dstFile.Close()
 
 	// Prepare buffer for copying
-	buffer := make([]byte, c.bufferSize)
+	buffer := make([]byte, c.bufferSizeFor(ctx))
 
 	// Copy with progress tracking
 	var written int64
@@ -76,6 +179,7 @@ func (c *Copier) Copy(ctx context.Context, src, dst string) (CopyResult, error)
 				return CopyResult{}, fmt.Errorf("short write")
 			}
 			written += int64(nw)
+			throttle(ctx, written, startTime)
 		}
 		if err != nil {
 			if err == io.EOF {
@@ -113,6 +217,326 @@ func (c *Copier) Copy(ctx context.Context, src, dst string) (CopyResult, error)
 	}, nil
 }
 
+// ErrCopyFailed is returned by copyParallel when a block's read-back
+// digest doesn't match the one its worker recorded at write time - the
+// local equivalent of a remote backend's block-list API disagreeing with
+// what the uploading workers claim to have written. A rclone Azure
+// backend incident once let concurrent block appenders silently
+// duplicate or drop blocks under a racy shared counter; copyParallel
+// guards against the same class of bug by re-reading every block's
+// region after all writes complete and comparing it against the digest
+// recorded when that block was written, in block order.
+var ErrCopyFailed = errors.New("parallel chunked copy failed block verification")
+
+// parallelBlock is what a copyParallel worker records for the block it
+// wrote, for the verification pass to check against.
+type parallelBlock struct {
+	offset int64
+	size   int64
+	digest string
+}
+
+// copyParallel copies src to dst as a sequence of fixed-size blocks,
+// written concurrently by up to c.maxThreads workers via WriteAt. Each
+// worker hashes its block as it reads it; once every block is written, a
+// verification pass re-reads each region from dst and confirms it still
+// matches the recorded digest before the copy is considered complete.
+func (c *Copier) copyParallel(ctx context.Context, src, dst string, srcSize int64) (CopyResult, error) {
+	startTime := time.Now()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer dstFile.Close()
+
+	if err := dstFile.Truncate(srcSize); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to preallocate destination: %w", err)
+	}
+
+	numBlocks := int((srcSize + c.parallelBlockSize - 1) / c.parallelBlockSize)
+	blocks := make([]parallelBlock, numBlocks)
+
+	sem := make(chan struct{}, c.maxThreads)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	var written int64
+
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+	}
+
+	for i := 0; i < numBlocks; i++ {
+		offset := int64(i) * c.parallelBlockSize
+		size := c.parallelBlockSize
+		if offset+size > srcSize {
+			size = srcSize - offset
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, offset, size int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			select {
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			default:
+			}
+
+			buf := make([]byte, size)
+			if _, err := srcFile.ReadAt(buf, offset); err != nil && err != io.EOF {
+				fail(fmt.Errorf("read block %d: %w", i, err))
+				return
+			}
+
+			h := hash.New(hash.SHA256)
+			h.Write(buf)
+			digest := hex.EncodeToString(h.Sum(nil))
+
+			if _, err := dstFile.WriteAt(buf, offset); err != nil {
+				fail(fmt.Errorf("write block %d: %w", i, err))
+				return
+			}
+			blocks[i] = parallelBlock{offset: offset, size: size, digest: digest}
+
+			mu.Lock()
+			written += size
+			mu.Unlock()
+			if c.OnBlockCopied != nil {
+				c.OnBlockCopied(size)
+			}
+		}(i, offset, size)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return CopyResult{Source: src, Destination: dst, BytesCopied: written, Duration: time.Since(startTime), Error: firstErr}, firstErr
+	}
+
+	for i, b := range blocks {
+		buf := make([]byte, b.size)
+		if _, err := dstFile.ReadAt(buf, b.offset); err != nil {
+			return CopyResult{}, fmt.Errorf("%w: reread block %d: %v", ErrCopyFailed, i, err)
+		}
+		h := hash.New(hash.SHA256)
+		h.Write(buf)
+		if hex.EncodeToString(h.Sum(nil)) != b.digest {
+			return CopyResult{}, fmt.Errorf("%w: block %d at offset %d does not match what was written", ErrCopyFailed, i, b.offset)
+		}
+	}
+
+	if err := dstFile.Sync(); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to sync file: %w", err)
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to get source info: %w", err)
+	}
+	if err := os.Chmod(dst, srcInfo.Mode()); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime()); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to set timestamps: %w", err)
+	}
+
+	return CopyResult{
+		Source:      src,
+		Destination: dst,
+		BytesCopied: written,
+		Duration:    time.Since(startTime),
+	}, nil
+}
+
+// copyChunked diffs src against the existing dst chunk-by-chunk and only
+// writes the chunks whose digest changed at their existing offset, seeking
+// over everything else. A chunk that needs writing at a new offset but
+// whose content already exists elsewhere in dst - or matches one already
+// seen in c.chunkCache from an earlier file this run - still gets written
+// (there's no other offset to skip to), but is counted in
+// CopyResult.ChunksReused rather than treated as new content, so a caller
+// can tell a moved/duplicated chunk from a genuinely changed one. If dst
+// can't be read at all, it falls back to copyFull.
+func (c *Copier) copyChunked(ctx context.Context, src, dst string, srcSize int64) (CopyResult, error) {
+	startTime := time.Now()
+
+	srcData, err := os.ReadFile(src)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	dstData, err := os.ReadFile(dst)
+	if err != nil {
+		return c.copyFull(ctx, src, dst)
+	}
+
+	ck := chunker.New()
+	srcChunks := ck.SplitBytes(srcData)
+	dstChunks := ck.SplitBytes(dstData)
+
+	// dstAt lets us check, for a given source chunk, whether dst already
+	// has that exact digest at that exact offset - the common case for an
+	// append or a localized edit, where most chunks don't move at all.
+	// dstHasDigest additionally recognizes a chunk that exists in dst but
+	// at a different offset (e.g. a block of reordered or duplicated
+	// content), which dstAt alone would treat as changed and rewrite.
+	dstAt := make(map[int64]string, len(dstChunks))
+	dstHasDigest := make(map[string]bool, len(dstChunks))
+	for _, chunk := range dstChunks {
+		dstAt[chunk.Offset] = chunk.Digest
+		dstHasDigest[chunk.Digest] = true
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY, 0644)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to open destination for chunked write: %w", err)
+	}
+	defer out.Close()
+
+	var written int64
+	var reused int
+	for _, chunk := range srcChunks {
+		select {
+		case <-ctx.Done():
+			return CopyResult{Source: src, Destination: dst, BytesCopied: written, ChunksReused: reused, Duration: time.Since(startTime), Error: ctx.Err()}, ctx.Err()
+		default:
+		}
+
+		data := srcData[chunk.Offset : chunk.Offset+chunk.Size]
+
+		// Already correct on disk at this offset - nothing to write.
+		if dstAt[chunk.Offset] == chunk.Digest {
+			c.chunkCache.Put(chunk.Digest, data)
+			continue
+		}
+
+		// This exact content already exists in dst, just at a different
+		// offset (or still held from an earlier file's chunk this run) -
+		// reuse it instead of treating it as new content. The bytes are
+		// identical either way, but the distinction matters for
+		// ChunksReused/BlockStore bookkeeping, which is what tells a
+		// caller how much of this copy was genuinely new.
+		if _, cached := c.chunkCache.Get(chunk.Digest); cached || dstHasDigest[chunk.Digest] {
+			reused++
+		}
+		c.chunkCache.Put(chunk.Digest, data)
+
+		n, err := out.WriteAt(data, chunk.Offset)
+		if err != nil {
+			return CopyResult{}, fmt.Errorf("write error: %w", err)
+		}
+		written += int64(n)
+	}
+
+	if err := out.Truncate(int64(len(srcData))); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to truncate destination: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to sync file: %w", err)
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to get source info: %w", err)
+	}
+	if err := os.Chmod(dst, srcInfo.Mode()); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime()); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to set timestamps: %w", err)
+	}
+
+	return CopyResult{
+		Source:       src,
+		Destination:  dst,
+		BytesCopied:  written,
+		ChunksReused: reused,
+		Duration:     time.Since(startTime),
+	}, nil
+}
+
+// CopyToRepository streams src into repo at dstPath. It's the path used
+// when the configured target is remote (SFTP, S3): unlike Copy/copyChunked,
+// which open src and dst as local file paths and write dst at offsets,
+// this always does a full streamed write, the repository.Repository
+// equivalent of copyFull.
+func (c *Copier) CopyToRepository(ctx context.Context, src string, repo repository.Repository, dstPath string) (CopyResult, error) {
+	startTime := time.Now()
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer srcFile.Close()
+
+	writer, err := repo.OpenWriter(dstPath, true)
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to open destination: %w", err)
+	}
+
+	buffer := make([]byte, c.bufferSizeFor(ctx))
+	var written int64
+	for {
+		select {
+		case <-ctx.Done():
+			writer.Close()
+			return CopyResult{Source: src, Destination: dstPath, BytesCopied: written, Duration: time.Since(startTime), Error: ctx.Err()}, ctx.Err()
+		default:
+		}
+
+		nr, readErr := srcFile.Read(buffer)
+		if nr > 0 {
+			nw, writeErr := writer.Write(buffer[0:nr])
+			if writeErr != nil {
+				writer.Close()
+				return CopyResult{}, fmt.Errorf("write error: %w", writeErr)
+			}
+			if nr != nw {
+				writer.Close()
+				return CopyResult{}, fmt.Errorf("short write")
+			}
+			written += int64(nw)
+			throttle(ctx, written, startTime)
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			}
+			writer.Close()
+			return CopyResult{}, fmt.Errorf("read error: %w", readErr)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to finalize destination: %w", err)
+	}
+
+	return CopyResult{
+		Source:      src,
+		Destination: dstPath,
+		BytesCopied: written,
+		Duration:    time.Since(startTime),
+	}, nil
+}
+
 // VerifyCopy verifies the integrity of a copied file
 func (c *Copier) VerifyCopy(src, dst string) error {
 	srcChecksum, err := calculateFullChecksum(src)
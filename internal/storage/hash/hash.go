@@ -0,0 +1,149 @@
+// internal/storage/hash/hash.go
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/zeebo/blake3"
+)
+
+// Type identifies a single hash algorithm as one bit of a Set, so a side of
+// a comparison can advertise every algorithm it supports cheaply in one
+// value.
+type Type uint32
+
+const (
+	None Type = 0
+
+	CRC32C Type = 1 << iota
+	XXHash
+	MD5
+	SHA1
+	SHA256
+	Blake3
+)
+
+func (t Type) String() string {
+	switch t {
+	case None:
+		return "none"
+	case CRC32C:
+		return "crc32c"
+	case XXHash:
+		return "xxhash"
+	case MD5:
+		return "md5"
+	case SHA1:
+		return "sha1"
+	case SHA256:
+		return "sha256"
+	case Blake3:
+		return "blake3"
+	default:
+		return fmt.Sprintf("hash.Type(%d)", uint32(t))
+	}
+}
+
+// Set is a bitmask of the Types a side of a comparison can produce.
+type Set Type
+
+// Has reports whether t is one of the types in s.
+func (s Set) Has(t Type) bool {
+	return Type(s)&t != 0
+}
+
+// Intersect returns the types present in both sets.
+func (s Set) Intersect(other Set) Set {
+	return Set(Type(s) & Type(other))
+}
+
+// Hasher describes which hash types a source or destination can produce
+// cheaply - a local filesystem can compute any of them by reading the file,
+// but a remote backend that already publishes a content hash (e.g. an S3
+// object's ETag) would report only that one, so comparisons prefer it over
+// re-hashing the whole object.
+type Hasher interface {
+	Hashes() Set
+	// Sum returns t's digest as lowercase hex. t is always one CheckHashes
+	// already confirmed is in Hashes().
+	Sum(t Type) (string, error)
+}
+
+// order ranks Types from cheapest to most expensive to compute, used to
+// pick a single type out of an intersection of two Sets. CRC32C and XXHash
+// are non-cryptographic and fastest; MD5/SHA1/SHA256/Blake3 follow in their
+// usual relative cost order.
+var order = []Type{CRC32C, XXHash, MD5, SHA1, SHA256, Blake3}
+
+// Pick returns the cheapest Type present in both sets, or ok=false if they
+// share nothing in common (e.g. one side reports None).
+func Pick(a, b Set) (t Type, ok bool) {
+	overlap := a.Intersect(b)
+	for _, candidate := range order {
+		if overlap.Has(candidate) {
+			return candidate, true
+		}
+	}
+	return None, false
+}
+
+// New returns a fresh hash.Hash for t, or nil if t is None or not a single
+// recognized type.
+func New(t Type) hash.Hash {
+	switch t {
+	case CRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case XXHash:
+		return xxhash.New()
+	case MD5:
+		return md5.New()
+	case SHA1:
+		return sha1.New()
+	case SHA256:
+		return sha256.New()
+	case Blake3:
+		return blake3.New()
+	default:
+		return nil
+	}
+}
+
+// Sum hashes r's entire content with every type in want in a single pass
+// (via io.MultiWriter), returning each as a lowercase hex digest.
+func Sum(r io.Reader, want Set) (map[Type]string, error) {
+	var types []Type
+	var writers []io.Writer
+	hashes := make(map[Type]hash.Hash)
+
+	for _, t := range order {
+		if !want.Has(t) {
+			continue
+		}
+		h := New(t)
+		types = append(types, t)
+		hashes[t] = h
+		writers = append(writers, h)
+	}
+
+	if len(writers) == 0 {
+		return map[Type]string{}, nil
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, fmt.Errorf("hash content: %w", err)
+	}
+
+	sums := make(map[Type]string, len(types))
+	for _, t := range types {
+		sums[t] = hex.EncodeToString(hashes[t].Sum(nil))
+	}
+	return sums, nil
+}
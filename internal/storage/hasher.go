@@ -0,0 +1,64 @@
+// internal/storage/hasher.go
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
+)
+
+// localHashes is every type a local filesystem path can produce, since
+// computing any of them just means reading the file once.
+const localHashes = hash.Set(hash.CRC32C | hash.XXHash | hash.MD5 | hash.SHA1 | hash.SHA256 | hash.Blake3)
+
+// FileHasher is a hash.Hasher backed by a local filesystem path. A future
+// remote backend (e.g. S3, publishing only the MD5 its ETag already
+// carries) would implement the same interface over its own metadata
+// instead of re-reading the object.
+type FileHasher struct {
+	Path string
+}
+
+func (f FileHasher) Hashes() hash.Set {
+	return localHashes
+}
+
+// Sum computes t's digest by reading the file once. Use CheckHashes rather
+// than calling Sum on both sides directly when comparing two FileHashers,
+// so a type neither side actually needs isn't wasted work.
+func (f FileHasher) Sum(t hash.Type) (string, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("open %s for hashing: %w", f.Path, err)
+	}
+	defer file.Close()
+
+	sums, err := hash.Sum(file, hash.Set(t))
+	if err != nil {
+		return "", err
+	}
+	return sums[t], nil
+}
+
+// prefixHasher limits hashing to the first limit bytes of the file, for
+// QuickHashCompare's cheap 64KB probe rather than a full-content digest.
+type prefixHasher struct {
+	FileHasher
+	limit int64
+}
+
+func (p prefixHasher) Sum(t hash.Type) (string, error) {
+	file, err := os.Open(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("open %s for hashing: %w", p.Path, err)
+	}
+	defer file.Close()
+
+	sums, err := hash.Sum(io.LimitReader(file, p.limit), hash.Set(t))
+	if err != nil {
+		return "", err
+	}
+	return sums[t], nil
+}
@@ -2,6 +2,7 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/jack-sneddon/backup-butler/internal/config"
 	"github.com/jack-sneddon/backup-butler/internal/types"
+	"github.com/jack-sneddon/backup-butler/internal/validation"
 )
 
 // Manager handles file operations
@@ -203,6 +205,58 @@ func (m *Manager) IsDirectory(path string) bool {
 	return info.IsDir()
 }
 
+// WildcardChecksum is one matched file's full SHA-256 digest from
+// ChecksumWildcard.
+type WildcardChecksum struct {
+	Path     string
+	Checksum string
+}
+
+// ChecksumWildcard walks root and computes a full SHA-256 digest for every
+// file whose path (relative to root) matches pattern, in a single pass.
+// It lets a caller verify just "my RAW photos this week" instead of paying
+// full-tree deep-validation cost.
+func (m *Manager) ChecksumWildcard(ctx context.Context, root, pattern string) ([]WildcardChecksum, error) {
+	glob := validation.NewGlobSet([]string{pattern})
+	calc := NewChecksumCalculator()
+
+	var results []WildcardChecksum
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path for %s: %w", path, err)
+		}
+		if !glob.MatchAny(filepath.ToSlash(relPath)) {
+			return nil
+		}
+
+		checksum, err := calc.CalculateChecksum(path)
+		if err != nil {
+			return fmt.Errorf("failed to checksum %s: %w", path, err)
+		}
+
+		results = append(results, WildcardChecksum{Path: relPath, Checksum: checksum})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s for wildcard checksum: %w", root, err)
+	}
+
+	return results, nil
+}
+
 func (m *Manager) GetIntegrityIssues() ([]*IntegrityCheck, error) {
 	issuesPath := filepath.Join(m.baseDir, ".versions", "integrity_issues.json")
 
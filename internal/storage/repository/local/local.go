@@ -0,0 +1,143 @@
+// Package local backs a repository.Repository with the local filesystem -
+// the default target, and exactly the behavior backup-butler has always
+// had for versions and mirrored files.
+package local
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository"
+)
+
+// localHashes is every type a local file can produce, since computing any
+// of them just means reading the file once - the same set storage.FileHasher
+// advertises for a plain local path.
+const localHashes = hash.Set(hash.CRC32C | hash.XXHash | hash.MD5 | hash.SHA1 | hash.SHA256 | hash.Blake3)
+
+// Repository roots every path at a directory on the local filesystem.
+type Repository struct {
+	root string
+}
+
+// New returns a Repository rooted at root.
+func New(root string) *Repository {
+	return &Repository{root: root}
+}
+
+func (r *Repository) resolve(path string) string {
+	return filepath.Join(r.root, path)
+}
+
+func (r *Repository) Stat(path string) (repository.Info, error) {
+	info, err := os.Stat(r.resolve(path))
+	if err != nil {
+		return repository.Info{}, err
+	}
+	return repository.Info{Name: path, Size: info.Size(), ModTime: info.ModTime(), IsDir: info.IsDir()}, nil
+}
+
+func (r *Repository) OpenReader(path string) (io.ReadCloser, error) {
+	return os.Open(r.resolve(path))
+}
+
+// OpenWriter returns a writer for path. When atomic is true it writes to a
+// sibling temp file and renames it into place on Close, the same pattern
+// version.Manager already used for index and version files before this
+// package existed.
+func (r *Repository) OpenWriter(path string, atomic bool) (io.WriteCloser, error) {
+	full := r.resolve(path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return nil, err
+	}
+
+	if !atomic {
+		return os.Create(full)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(full), filepath.Base(full)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &atomicWriter{File: tmp, finalPath: full}, nil
+}
+
+type atomicWriter struct {
+	*os.File
+	finalPath string
+}
+
+func (w *atomicWriter) Close() error {
+	if err := w.File.Close(); err != nil {
+		os.Remove(w.File.Name())
+		return err
+	}
+	return os.Rename(w.File.Name(), w.finalPath)
+}
+
+func (r *Repository) Remove(path string) error {
+	return os.Remove(r.resolve(path))
+}
+
+// List walks root looking for every regular file whose path relative to
+// root starts with prefix, so a prefix of either a directory ("backups/")
+// or a partial filename selects the same entries os.ReadDir would for a
+// single directory, plus anything nested deeper.
+func (r *Repository) List(prefix string) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(r.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, relErr := filepath.Rel(r.root, path)
+		if relErr != nil {
+			return relErr
+		}
+		if rel == "." || d.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(rel, prefix) {
+			names = append(names, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (r *Repository) Rename(oldPath, newPath string) error {
+	return os.Rename(r.resolve(oldPath), r.resolve(newPath))
+}
+
+func (r *Repository) Hashes() hash.Set {
+	return localHashes
+}
+
+// Features reports case/normalization behavior by GOOS rather than probing
+// the actual filesystem: APFS (darwin) and NTFS (windows) both fold case
+// and Unicode-normalize by default, while ext4 and most Linux filesystems
+// do neither. A root mounted on an atypically-configured filesystem (a
+// case-sensitive APFS volume, an exFAT share on Linux) won't be detected
+// correctly, but GOOS is the same good-enough heuristic rclone's backends
+// use before a user overrides it explicitly.
+func (r *Repository) Features() repository.Features {
+	insensitive := runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+	return repository.Features{
+		Atomic:                   true,
+		RangeWrite:               true,
+		CaseInsensitive:          insensitive,
+		NormalizationInsensitive: insensitive,
+	}
+}
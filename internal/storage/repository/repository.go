@@ -0,0 +1,77 @@
+// Package repository abstracts the backing store a version.Manager (or a
+// Copier) writes to, so the same code path works whether the configured
+// target is a local directory, an SFTP server, or an S3-compatible bucket -
+// the same seam rclone and restic use to support many backends behind one
+// implementation.
+package repository
+
+import (
+	"io"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/hash"
+)
+
+// Info is the subset of file metadata every Repository implementation can
+// report, regardless of what's actually backing the store. Digest carries
+// any content hash the backend already knew without reading the object -
+// an S3 ETag, for instance - keyed by the hash.Type it actually is; a type
+// missing from the map just means this Stat didn't come with one, not that
+// the backend can never produce it (see Hashes).
+type Info struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+	Digest  map[hash.Type]string
+}
+
+// Features describes what a Repository implementation can do beyond the
+// baseline Stat/OpenReader/OpenWriter/Remove/List/Rename, so a caller can
+// pick a strategy (e.g. chunked parallel writes) without type-asserting
+// the concrete Repository.
+type Features struct {
+	// Atomic reports whether OpenWriter(path, true) actually makes the
+	// write atomic, rather than silently behaving like atomic=false.
+	Atomic bool
+	// RangeWrite reports whether the backend can write to an arbitrary
+	// offset within an object (e.g. local WriteAt, SFTP seek+write), which
+	// a parallel chunked copy needs to place blocks out of order.
+	RangeWrite bool
+	// CaseInsensitive reports whether two paths differing only in case
+	// (e.g. "Foo.txt" vs "foo.txt") name the same object on this backend -
+	// true for APFS/HFS+ and NTFS in their default configuration, false
+	// for ext4 and most object stores.
+	CaseInsensitive bool
+	// NormalizationInsensitive reports whether the backend itself folds
+	// Unicode-equivalent paths (NFC vs NFD forms of the same string) to
+	// the same object, the way APFS does. A caller comparing paths across
+	// two Repositories where either side is normalization-insensitive
+	// should fold both to NFC before comparing, or it will treat an
+	// NFD-decomposed name from one side and the NFC-precomposed form of
+	// the identical name from the other as two different files.
+	NormalizationInsensitive bool
+}
+
+// Repository is a backing store a version.Manager or Copier can read from
+// and write to without knowing whether it's local disk, SFTP, or S3.
+type Repository interface {
+	Stat(path string) (Info, error)
+	OpenReader(path string) (io.ReadCloser, error)
+	// OpenWriter returns a writer for path. When atomic is true, the write
+	// is only made visible at path once Close succeeds, so a crash or
+	// cancellation mid-write never leaves a partial file in its place.
+	OpenWriter(path string, atomic bool) (io.WriteCloser, error)
+	Remove(path string) error
+	// List returns the paths of every entry whose path starts with prefix.
+	List(prefix string) ([]string, error)
+	Rename(oldPath, newPath string) error
+	// Hashes reports which hash.Types this Repository can produce for an
+	// object - for local that's every type storage/hash knows, since
+	// computing any of them just means reading the file; SFTP reports
+	// hash.None (see sftp.Repository.Hashes); S3 reports
+	// only hash.MD5, since that's the one Stat can sometimes read straight
+	// off an object's ETag instead of downloading it.
+	Hashes() hash.Set
+	Features() Features
+}
@@ -12,4 +12,11 @@ type CopyResult struct {
 	BytesCopied int64         `json:"bytes_copied"`
 	Duration    time.Duration `json:"duration"`
 	Error       error         `json:"error,omitempty"`
+
+	// ChunksReused counts chunks copyChunked satisfied from dst's own
+	// existing content (same digest at a different offset, or a digest
+	// still held in the Copier's chunk cache from an earlier file in this
+	// run). copyChunked still writes those chunks, since their bytes are
+	// identical either way. Zero for every copy path other than copyChunked.
+	ChunksReused int `json:"chunks_reused,omitempty"`
 }
@@ -3,6 +3,7 @@ package sync
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -38,8 +39,89 @@ type RsyncOptions struct {
 // RsyncStats contains statistics from an rsync operation
 type RsyncStats struct {
 	FilesTransferred int
-	TotalSize        string
-	Duration         time.Duration
+	// FilesNew, FilesChanged, FilesAttrOnly, and FilesDeleted break
+	// FilesTransferred down by itemize kind - parsed from the
+	// --out-format stream rather than the summary --stats block, which
+	// only reports a single aggregate transfer count.
+	FilesNew      int
+	FilesChanged  int
+	FilesAttrOnly int
+	FilesDeleted  int
+	TotalSize     string
+	Duration      time.Duration
+}
+
+// RsyncEventSink is the subset of progress.Tracker runForSingleDirectory
+// feeds file-level byte counts into as it parses rsync's --out-format
+// stream, so an rsync-driven sync can report progress through the same
+// Tracker a native copy uses instead of its own ad-hoc "\rProgress: N%"
+// line. StartDirectory/FinishDirectory aren't part of this interface:
+// rsync doesn't report a directory's total file count or byte total
+// up front, only as each file completes, so there's nothing honest to pass
+// them.
+type RsyncEventSink interface {
+	UpdateProgress(bytes int64)
+}
+
+// ItemizeKind categorizes one --out-format line's update type, folding
+// rsync's 11-character itemize code down to the new/changed/attribute-only
+// buckets RsyncStats counts separately, plus the "*deleting" line --delete
+// emits for a removed destination file.
+type ItemizeKind int
+
+const (
+	ItemizeUnknown ItemizeKind = iota
+	ItemizeNew
+	ItemizeChanged
+	ItemizeAttrOnly
+	ItemizeDeleted
+)
+
+// ItemizeEntry is one parsed "%i|%n|%l|%b" line.
+type ItemizeEntry struct {
+	Kind   ItemizeKind
+	Name   string
+	Length int64 // %l: the file's total size
+	Bytes  int64 // %b: bytes actually transferred for this file
+}
+
+// parseItemizeLine parses one line of rsync's --out-format='%i|%n|%l|%b'
+// output. It reports ok=false for lines that aren't an itemize record -
+// --info=progress2's percentage lines and the closing --stats summary
+// share the same stdout stream and don't match this shape.
+//
+// The itemize code's first two characters are the update type (<, >, c,
+// h, ., or * for "deleting") and file type (f, d, L, D, S); the remaining
+// nine are per-attribute flags (checksum, size, time, perm, owner, group,
+// and others), '.' meaning unchanged and '+' meaning "new file, everything
+// is new". A newly transferred file reports all nine as '+'; an existing
+// file with its update type '.' (no transfer needed) but at least one
+// flag set changed only metadata, not content.
+func parseItemizeLine(line string) (ItemizeEntry, bool) {
+	parts := strings.SplitN(line, "|", 4)
+	if len(parts) != 4 {
+		return ItemizeEntry{}, false
+	}
+
+	code, name := parts[0], parts[1]
+	length, _ := strconv.ParseInt(parts[2], 10, 64)
+	bytes, _ := strconv.ParseInt(parts[3], 10, 64)
+	entry := ItemizeEntry{Name: name, Length: length, Bytes: bytes}
+
+	switch {
+	case strings.HasPrefix(code, "*deleting"):
+		entry.Kind = ItemizeDeleted
+	case len(code) != 11:
+		return ItemizeEntry{}, false
+	case strings.Count(code[2:], "+") == 9:
+		entry.Kind = ItemizeNew
+	case code[0] == '.':
+		entry.Kind = ItemizeAttrOnly
+	default:
+		entry.Kind = ItemizeChanged
+	}
+
+	return entry, true
 }
 
 // buildBaseCommand constructs the base rsync command
@@ -51,8 +133,11 @@ func buildBaseCommand(opts RsyncOptions) []string {
 		// We don't want to see every file, just statistics
 		args = append(args, "-n")
 	} else {
-		// For actual sync, show progress
-		args = append(args, "--info=progress2")
+		// For actual sync, show the aggregate percentage line plus a
+		// structured per-file record (itemize code, name, size, bytes
+		// transferred) that runForSingleDirectory's scanner parses into
+		// ItemizeEntry instead of scraping rsync's human-readable output.
+		args = append(args, "--info=progress2", "--out-format=%i|%n|%l|%b")
 	}
 
 	// Add delete flag if specified
@@ -68,8 +153,10 @@ func buildBaseCommand(opts RsyncOptions) []string {
 	return args
 }
 
-// runForSingleDirectory executes rsync for a single directory
-func runForSingleDirectory(dir, source, target string, baseArgs []string, logger *slog.Logger) (string, error) {
+// runForSingleDirectory executes rsync for a single directory. sink may be
+// nil, in which case the parsed itemize stream is still tallied into the
+// returned RsyncStats but no progress events are emitted anywhere.
+func runForSingleDirectory(ctx context.Context, dir, source, target string, baseArgs []string, logger *slog.Logger, sink RsyncEventSink) (string, RsyncStats, error) {
 	sourceDir := source
 	targetDir := target
 
@@ -77,62 +164,81 @@ func runForSingleDirectory(dir, source, target string, baseArgs []string, logger
 	if dir != "" {
 		sourceDir = fmt.Sprintf("%s/%s/", source, dir)
 		targetDir = fmt.Sprintf("%s/%s/", target, dir)
-		
+
 		// Ensure target directory exists
 		if err := os.MkdirAll(targetDir, 0755); err != nil {
 			logger.Error("Failed to create target directory",
 				"directory", targetDir,
 				"error", err)
-			return "", fmt.Errorf("failed to create target directory %s: %w", targetDir, err)
+			return "", RsyncStats{}, fmt.Errorf("failed to create target directory %s: %w", targetDir, err)
 		}
 	}
 
 	args := append([]string{}, baseArgs...)
 	args = append(args, sourceDir, targetDir)
 
-	cmd := exec.Command("rsync", args...)
-	
-	logger.Info("Running rsync command", 
+	cmd := exec.CommandContext(ctx, "rsync", args...)
+
+	logger.Info("Running rsync command",
 		"command", fmt.Sprintf("rsync %s", strings.Join(args, " ")),
 		"sourceDir", sourceDir,
 		"targetDir", targetDir)
-		
+
 	fmt.Printf("Running rsync: %s to %s\n", sourceDir, targetDir)
 
 	// Create buffers for stdout and stderr
 	var stdoutBuf, stderrBuf bytes.Buffer
-	
+
 	// Set up pipes for command output
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
-		return "", fmt.Errorf("error creating stdout pipe: %w", err)
+		return "", RsyncStats{}, fmt.Errorf("error creating stdout pipe: %w", err)
 	}
-	
+
 	stderrPipe, err := cmd.StderrPipe()
 	if err != nil {
-		return "", fmt.Errorf("error creating stderr pipe: %w", err)
+		return "", RsyncStats{}, fmt.Errorf("error creating stderr pipe: %w", err)
 	}
-	
+
 	// Start the command
 	if err := cmd.Start(); err != nil {
-		return "", fmt.Errorf("error starting rsync: %w", err)
+		return "", RsyncStats{}, fmt.Errorf("error starting rsync: %w", err)
 	}
-	
+
 	// Create a wait group to wait for both goroutines to finish
 	var wg sync.WaitGroup
 	wg.Add(2)
-	
+
+	var itemized RsyncStats
+
 	// Process stdout
 	go func() {
 		defer wg.Done()
-		
+
 		scanner := bufio.NewScanner(stdoutPipe)
 		progressRegex := regexp.MustCompile(`^\s*(\d+)%`)
-		
+
 		for scanner.Scan() {
 			line := scanner.Text()
 			stdoutBuf.WriteString(line + "\n")
-			
+
+			if entry, ok := parseItemizeLine(line); ok {
+				switch entry.Kind {
+				case ItemizeNew:
+					itemized.FilesNew++
+				case ItemizeChanged:
+					itemized.FilesChanged++
+				case ItemizeAttrOnly:
+					itemized.FilesAttrOnly++
+				case ItemizeDeleted:
+					itemized.FilesDeleted++
+				}
+				if sink != nil && entry.Bytes > 0 {
+					sink.UpdateProgress(entry.Bytes)
+				}
+				continue
+			}
+
 			// Check if this is a progress line
 			if matches := progressRegex.FindStringSubmatch(line); len(matches) > 1 {
 				percent, _ := strconv.Atoi(matches[1])
@@ -142,19 +248,19 @@ func runForSingleDirectory(dir, source, target string, baseArgs []string, logger
 					fmt.Println()
 				}
 			} else if strings.HasPrefix(line, "Number of files:") ||
-			          strings.HasPrefix(line, "Number of regular files transferred:") ||
-			          strings.HasPrefix(line, "Total file size:") || 
-			          strings.HasPrefix(line, "Total transferred file size:") {
+				strings.HasPrefix(line, "Number of regular files transferred:") ||
+				strings.HasPrefix(line, "Total file size:") ||
+				strings.HasPrefix(line, "Total transferred file size:") {
 				// Only print summary statistics
 				fmt.Println(line)
 			}
 		}
 	}()
-	
+
 	// Process stderr
 	go func() {
 		defer wg.Done()
-		
+
 		scanner := bufio.NewScanner(stderrPipe)
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -162,50 +268,58 @@ func runForSingleDirectory(dir, source, target string, baseArgs []string, logger
 			fmt.Fprintf(os.Stderr, "ERROR: %s\n", line)
 		}
 	}()
-	
+
 	// Wait for processing to complete
 	wg.Wait()
-	
+
 	// Wait for the command to finish
 	err = cmd.Wait()
-	
+
 	// Combine stdout and stderr
 	output := stdoutBuf.String() + stderrBuf.String()
-	
+
 	// Log summary
 	logger.Info("Rsync finished",
 		"stdout_size", stdoutBuf.Len(),
 		"stderr_size", stderrBuf.Len(),
 		"error", err)
-	
-	return output, err
+
+	return output, itemized, err
 }
 
-// parseRsyncStats extracts statistics from rsync output
+// parseRsyncStats extracts the aggregate file count and size from rsync's
+// closing --stats block. The itemize-kind breakdown (new/changed/
+// attribute-only/deleted) comes from runForSingleDirectory's line-by-line
+// parse instead, since --stats only reports a single transferred count.
 func parseRsyncStats(output string) RsyncStats {
 	var stats RsyncStats
-	
+
 	// Extract files transferred
 	fileRegex := regexp.MustCompile(`Number of regular files transferred: (\d+)`)
 	if matches := fileRegex.FindStringSubmatch(output); len(matches) > 1 {
 		files, _ := strconv.Atoi(matches[1])
 		stats.FilesTransferred = files
 	}
-	
+
 	// Extract total size
 	sizeRegex := regexp.MustCompile(`Total transferred file size: ([\d.,]+ [A-Za-z]+)`)
 	if matches := sizeRegex.FindStringSubmatch(output); len(matches) > 1 {
 		stats.TotalSize = matches[1]
 	}
-	
+
 	return stats
 }
 
-// RunRsync executes an rsync operation
-func RunRsync(op RsyncOperation, opts RsyncOptions, logger *slog.Logger) (RsyncStats, error) {
+// RunRsync executes an rsync operation. A cancelled ctx aborts the running
+// rsync process (via exec.CommandContext) instead of letting it finish.
+// sink receives a running byte count as each transferred file completes, fed
+// from rsync's own --out-format stream rather than a local estimate. It may
+// be nil, in which case RunRsync behaves exactly as before progress.Tracker
+// was wired in.
+func RunRsync(ctx context.Context, op RsyncOperation, opts RsyncOptions, logger *slog.Logger, sink RsyncEventSink) (RsyncStats, error) {
 	startTime := time.Now()
 	var stats RsyncStats
-	
+
 	// Set operation-specific flags
 	switch op {
 	case AnalyzeChanges:
@@ -221,25 +335,29 @@ func RunRsync(op RsyncOperation, opts RsyncOptions, logger *slog.Logger) (RsyncS
 		opts.DryRun = false
 		opts.Delete = true
 	}
-	
+
 	// Build the base command arguments after setting operation flags
 	baseArgs := buildBaseCommand(opts)
-	
+
 	// Check if we're using include directories
 	if len(opts.IncludeDirs) > 0 {
 		// Run rsync for each included directory
 		for _, dir := range opts.IncludeDirs {
 			logger.Info("Processing directory", "directory", dir)
 			fmt.Printf("\nProcessing directory: %s\n", dir)
-			
-			output, err := runForSingleDirectory(dir, opts.Source, opts.Target, baseArgs, logger)
+
+			output, itemized, err := runForSingleDirectory(ctx, dir, opts.Source, opts.Target, baseArgs, logger, sink)
 			if err != nil {
 				return stats, err
 			}
-			
+
 			// Parse stats from this directory's output
 			dirStats := parseRsyncStats(output)
 			stats.FilesTransferred += dirStats.FilesTransferred
+			stats.FilesNew += itemized.FilesNew
+			stats.FilesChanged += itemized.FilesChanged
+			stats.FilesAttrOnly += itemized.FilesAttrOnly
+			stats.FilesDeleted += itemized.FilesDeleted
 			// Note: Total size will be from the last directory processed
 			if dirStats.TotalSize != "" {
 				stats.TotalSize = dirStats.TotalSize
@@ -247,67 +365,73 @@ func RunRsync(op RsyncOperation, opts RsyncOptions, logger *slog.Logger) (RsyncS
 		}
 	} else {
 		// Run rsync for the entire source/target
-		output, err := runForSingleDirectory("", opts.Source, opts.Target, baseArgs, logger)
+		output, itemized, err := runForSingleDirectory(ctx, "", opts.Source, opts.Target, baseArgs, logger, sink)
 		if err != nil {
 			return stats, err
 		}
-		
+
 		stats = parseRsyncStats(output)
+		stats.FilesNew = itemized.FilesNew
+		stats.FilesChanged = itemized.FilesChanged
+		stats.FilesAttrOnly = itemized.FilesAttrOnly
+		stats.FilesDeleted = itemized.FilesDeleted
 	}
-	
+
 	// Calculate duration
 	stats.Duration = time.Since(startTime)
-	
+
 	return stats, nil
 }
 
-// CountDeletions counts the number of files that would be deleted
-func CountDeletions(opts RsyncOptions, logger *slog.Logger) (int, []string, error) {
+// CountDeletions counts the number of files that would be deleted. A
+// cancelled ctx aborts the running rsync process instead of letting it
+// finish.
+func CountDeletions(ctx context.Context, opts RsyncOptions, logger *slog.Logger) (int, []string, error) {
 	count := 0
 	var sampleDeletions []string
-	
+
 	// Ensure this is a dry run with delete flag
 	opts.DryRun = true
 	opts.Delete = true
 	baseArgs := buildBaseCommand(opts)
-	
+
 	// Function to process a single directory
 	processDeletions := func(dir, source, target string) (int, []string, error) {
 		sourceDir := source
 		targetDir := target
-		
+
 		if dir != "" {
 			sourceDir = fmt.Sprintf("%s/%s/", source, dir)
 			targetDir = fmt.Sprintf("%s/%s/", target, dir)
 		}
-		
+
 		args := append([]string{}, baseArgs...)
 		args = append(args, sourceDir, targetDir)
-		
-		cmd := exec.Command("rsync", args...)
-		logger.Info("Running deletion analysis", 
+
+		cmd := exec.CommandContext(ctx, "rsync", args...)
+		logger.Info("Running deletion analysis",
 			"command", fmt.Sprintf("rsync %s", strings.Join(args, " ")))
-		
+
 		fmt.Printf("Analyzing potential deletions: %s to %s\n", sourceDir, targetDir)
-		
+
 		var stdout bytes.Buffer
 		var stderr bytes.Buffer
 		cmd.Stdout = &stdout
 		cmd.Stderr = &stderr
-		
+
 		err := cmd.Run()
 		if err != nil {
-			logger.Error("Deletion analysis failed", 
+			logger.Error("Deletion analysis failed",
 				"stdout", stdout.String(),
 				"stderr", stderr.String(),
 				"error", err)
 			return 0, nil, fmt.Errorf("error running deletion analysis: %w", err)
 		}
-		
+
 		// Count deletions
 		localCount := 0
 		var localSamples []string
-		
+
 		scanner := bufio.NewScanner(&stdout)
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -320,20 +444,20 @@ func CountDeletions(opts RsyncOptions, logger *slog.Logger) (int, []string, erro
 				}
 			}
 		}
-		
+
 		return localCount, localSamples, nil
 	}
-	
+
 	// Check if we're using include directories
 	if len(opts.IncludeDirs) > 0 {
 		for _, dir := range opts.IncludeDirs {
 			// Don't print this for each directory to reduce verbosity
-		// fmt.Printf("\nAnalyzing deletions for directory: %s\n", dir)
+			// fmt.Printf("\nAnalyzing deletions for directory: %s\n", dir)
 			localCount, localSamples, err := processDeletions(dir, opts.Source, opts.Target)
 			if err != nil {
 				return count, sampleDeletions, err
 			}
-			
+
 			count += localCount
 			if len(sampleDeletions) < 5 {
 				remainingSlots := 5 - len(sampleDeletions)
@@ -349,10 +473,10 @@ func CountDeletions(opts RsyncOptions, logger *slog.Logger) (int, []string, erro
 		if err != nil {
 			return count, sampleDeletions, err
 		}
-		
+
 		count = localCount
 		sampleDeletions = localSamples
 	}
-	
+
 	return count, sampleDeletions, nil
-}
\ No newline at end of file
+}
@@ -7,11 +7,13 @@ const (
 	Quick    ValidationLevel = "quick"    // Size and modification time only
 	Standard ValidationLevel = "standard" // Includes partial content hash
 	Deep     ValidationLevel = "deep"     // Full content verification
+	Chunked  ValidationLevel = "chunked"  // Content-defined chunk digest comparison
+	Wildcard ValidationLevel = "wildcard" // Full hash for glob matches, standard probe elsewhere
 )
 
 func IsValidLevel(level string) bool {
 	switch ValidationLevel(level) {
-	case Quick, Standard, Deep:
+	case Quick, Standard, Deep, Chunked, Wildcard:
 		return true
 	}
 	return false
@@ -21,16 +21,16 @@ func NewBackupCommand(service backup.BackupService, formatter *formatter.OutputF
 	}
 }
 
-func (c *BackupCommand) Backup() int {
-	if err := c.service.Backup(context.Background()); err != nil {
+func (c *BackupCommand) Backup(ctx context.Context) int {
+	if err := c.service.Backup(ctx); err != nil {
 		fmt.Println(c.formatter.FormatError(err))
 		return 1
 	}
 	return 0
 }
 
-func (c *BackupCommand) DryRun() int {
-	if err := c.service.DryRun(context.Background()); err != nil {
+func (c *BackupCommand) DryRun(ctx context.Context) int {
+	if err := c.service.DryRun(ctx); err != nil {
 		fmt.Println(c.formatter.FormatError(err))
 		return 1
 	}
@@ -2,8 +2,12 @@
 package commands
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/jack-sneddon/backup-butler/internal/app"
 	"github.com/jack-sneddon/backup-butler/internal/ui/cli/formatter"
@@ -17,7 +21,26 @@ type cli struct {
 	listVersions  bool
 	showVersion   string
 	latestVersion bool
-	formatter     *formatter.OutputFormatter
+	outputFlag    string
+	// rehashFlag is parsed but nothing reads it: the hexagonal backup path
+	// this CLI drives doesn't consult the validation package's checksum
+	// cache, so the flag has no effect here.
+	rehashFlag bool
+	// restartFlag discards any checkpoint journal left by a previous,
+	// interrupted run against the same target instead of resuming from
+	// it. Resuming is the default - see app.Factory.CreateBackupService.
+	restartFlag bool
+	// upgradeFlag migrates the target's on-disk repository format to
+	// versionadapter.CurrentRepositoryVersion instead of running a backup.
+	// Required before any other operation once CreateBackupService starts
+	// reporting a versionadapter.ErrUpgradeRequired error.
+	upgradeFlag bool
+	// doctorFlag runs the same HealthCheck probes as -validate and then
+	// exits without running a backup - a standalone diagnostic a user can
+	// reach for when a backup is failing. Like -validate, it still needs
+	// -config and builds the service from it first.
+	doctorFlag bool
+	formatter  *formatter.OutputFormatter
 }
 
 func NewCLI() *cli {
@@ -34,6 +57,11 @@ func (c *cli) ParseFlags() {
 	flag.BoolVar(&c.listVersions, "list-versions", false, "List all backup versions")
 	flag.StringVar(&c.showVersion, "show-version", "", "Show details of a specific backup version")
 	flag.BoolVar(&c.latestVersion, "latest-version", false, "Show most recent backup details")
+	flag.StringVar(&c.outputFlag, "output", "text", "Progress output format: text (live status UI) or json (structured event stream)")
+	flag.BoolVar(&c.rehashFlag, "rehash", false, "Accepted but has no effect: the backup this command runs does not use the validation checksum cache")
+	flag.BoolVar(&c.restartFlag, "restart", false, "Discard any checkpoint journal from a previous interrupted run instead of resuming from it")
+	flag.BoolVar(&c.upgradeFlag, "upgrade", false, "Migrate the target's on-disk repository format to the version this build expects")
+	flag.BoolVar(&c.doctorFlag, "doctor", false, "Check that the target directory, version manifest, and worker pool are all usable, then exit")
 	flag.Parse()
 }
 
@@ -49,18 +77,39 @@ func (c *cli) Execute() int {
 		return 1
 	}
 
+	jsonMode := c.outputFlag == "json"
+	if c.outputFlag != "text" && c.outputFlag != "json" {
+		fmt.Printf("Error: -output must be \"text\" or \"json\", got %q.\n", c.outputFlag)
+		return 1
+	}
+
+	if c.upgradeFlag {
+		if err := app.NewFactory(c.configPath).UpgradeRepository(); err != nil {
+			fmt.Println(c.formatter.FormatError(err))
+			return 1
+		}
+		fmt.Println("Repository upgraded successfully")
+		return 0
+	}
+
+	if c.doctorFlag {
+		return c.runHealthCheck()
+	}
+
 	if c.validateFlag {
 		// Config validation happens during service creation
-		if _, err := app.NewFactory(c.configPath).CreateBackupService(); err != nil {
+		if _, err := app.NewFactory(c.configPath).CreateBackupService(jsonMode, c.restartFlag); err != nil {
 			fmt.Printf("Configuration invalid: %v\n", err)
 			return 1
 		}
-		fmt.Println("Configuration is valid")
-		return 0
+		// Construction alone no longer proves the target is usable - most
+		// components are built lazily now (see app.Factory.CreateBackupService) -
+		// so run the same probes -doctor does before declaring it valid.
+		return c.runHealthCheck()
 	}
 
 	factory := app.NewFactory(c.configPath)
-	service, err := factory.CreateBackupService()
+	service, err := factory.CreateBackupService(jsonMode, c.restartFlag)
 	if err != nil {
 		fmt.Println(c.formatter.FormatError(err))
 		return 1
@@ -92,9 +141,49 @@ func (c *cli) Execute() int {
 		return 0
 	}
 
+	// A first SIGINT/SIGTERM cancels ctx so the worker pool stops feeding
+	// new tasks and aborts the copies in flight; stop() then unregisters
+	// the handler, so a second one falls through to the OS default
+	// disposition (process termination), which is the hard abort restic's
+	// own ctx.Cancel() escalation amounts to.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	backupCmd := NewBackupCommand(service, c.formatter)
 	if c.dryRunFlag {
-		return backupCmd.DryRun()
+		return backupCmd.DryRun(ctx)
+	}
+	return backupCmd.Backup(ctx)
+}
+
+// runHealthCheck builds a BackupService and, if it implements the optional
+// HealthCheck capability (backupservice.BackupServiceAdapter does), runs it.
+// Shared by -validate and -doctor, which differ only in when they're useful:
+// -validate is the pre-flight check before a run, -doctor is a standalone
+// diagnostic for troubleshooting one that already failed.
+func (c *cli) runHealthCheck() int {
+	service, err := app.NewFactory(c.configPath).CreateBackupService(false, c.restartFlag)
+	if err != nil {
+		fmt.Printf("Configuration invalid: %v\n", err)
+		return 1
+	}
+
+	checker, ok := service.(interface {
+		HealthCheck(ctx context.Context) error
+	})
+	if !ok {
+		fmt.Println("Configuration is valid")
+		return 0
+	}
+
+	if err := checker.HealthCheck(context.Background()); err != nil {
+		fmt.Printf("Health check failed: %v\n", err)
+		return 1
 	}
-	return backupCmd.Backup()
+	fmt.Println("Configuration is valid")
+	return 0
 }
@@ -2,11 +2,16 @@
 package formatter
 
 import (
+	"encoding/csv"
 	"fmt"
+	"html"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/jack-sneddon/backup-butler/internal/config"
 	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/scan"
 )
 
 const (
@@ -82,6 +87,12 @@ func (f *OutputFormatter) FormatVersionDetails(version *backup.BackupVersion) st
 	fmt.Fprintf(&output, "  Files Failed: %d\n", version.Stats.FilesFailed)
 	fmt.Fprintf(&output, "  Total Size: %.2f MB\n", float64(version.Stats.TotalBytes)/megabyte)
 	fmt.Fprintf(&output, "  Data Transferred: %.2f MB\n", float64(version.Stats.BytesTransferred)/megabyte)
+	if version.Stats.CacheHits+version.Stats.CacheMisses > 0 {
+		fmt.Fprintf(&output, "  Checksum Cache: %d hit(s), %d miss(es)\n", version.Stats.CacheHits, version.Stats.CacheMisses)
+	}
+	if version.Stats.BytesReused > 0 {
+		fmt.Fprintf(&output, "  Delta Copy Reused: %.2f MB\n", float64(version.Stats.BytesReused)/megabyte)
+	}
 
 	output.WriteString("\nConfiguration Used:\n")
 	fmt.Fprintf(&output, "  Source Directory: %s\n", version.ConfigUsed.SourceDirectory)
@@ -95,3 +106,275 @@ func (f *OutputFormatter) FormatVersionDetails(version *backup.BackupVersion) st
 func (f *OutputFormatter) FormatError(err error) string {
 	return fmt.Sprintf("Error: %v", err)
 }
+
+// ResultsFormatter renders a check run's comparisons, letting check pick a
+// concrete implementation from its --output flag instead of hard-coding the
+// tree-formatted text output.
+type ResultsFormatter interface {
+	FormatResults(w io.Writer, comparisons []*scan.FileComparison, progress *scan.Progress, cfg *config.Config) error
+}
+
+// TextResultsFormatter reproduces check's original tree-formatted console
+// output.
+type TextResultsFormatter struct{}
+
+func NewTextResultsFormatter() *TextResultsFormatter {
+	return &TextResultsFormatter{}
+}
+
+func (f *TextResultsFormatter) FormatResults(w io.Writer, comparisons []*scan.FileComparison, progress *scan.Progress, cfg *config.Config) error {
+	fmt.Fprintf(w, "\nScan Results:\n")
+	fmt.Fprintf(w, "├── Locations\n")
+	fmt.Fprintf(w, "│   ├── Source: %s\n", cfg.Source)
+	fmt.Fprintf(w, "│   └── Target: %s\n", cfg.Target)
+
+	fmt.Fprintf(w, "├── Summary\n")
+	fmt.Fprintf(w, "│   ├── Directories: %d\n", progress.ScannedDirs)
+	fmt.Fprintf(w, "│   ├── Files: %d\n", progress.ScannedFiles)
+	fmt.Fprintf(w, "│   ├── Total Size: %s\n", formatBytes(progress.TotalBytes))
+	if progress.ExcludedFiles > 0 || progress.ExcludedDirs > 0 {
+		fmt.Fprintf(w, "│   ├── Excluded Files: %d\n", progress.ExcludedFiles)
+		fmt.Fprintf(w, "│   └── Excluded Directories: %d\n", progress.ExcludedDirs)
+	} else {
+		fmt.Fprintf(w, "│   └── No Exclusions\n")
+	}
+
+	if len(progress.Errors) > 0 {
+		fmt.Fprintf(w, "├── Scan Errors\n")
+		for i, err := range progress.Errors {
+			if i == len(progress.Errors)-1 {
+				fmt.Fprintf(w, "│   └── %s\n", err)
+			} else {
+				fmt.Fprintf(w, "│   ├── %s\n", err)
+			}
+		}
+	}
+
+	var matches, new, missing, differs, errors int
+	fmt.Fprintf(w, "└── File Status\n")
+	for _, comp := range comparisons {
+		switch comp.Status {
+		case scan.StatusMatch:
+			matches++
+		case scan.StatusNew:
+			new++
+		case scan.StatusMissing:
+			missing++
+		case scan.StatusDiffer:
+			differs++
+		case scan.StatusError:
+			errors++
+		}
+		levelStr := ""
+		if comp.Level != "" {
+			levelStr = fmt.Sprintf(" [%s]", string(comp.Level))
+		}
+		fmt.Fprintf(w, "    %c %s%s\n", comp.Status, comp.Path, levelStr)
+	}
+
+	fmt.Fprintf(w, "\nResults Summary:\n")
+	fmt.Fprintf(w, "├── Matched:  %d files\n", matches)
+	fmt.Fprintf(w, "├── New:      %d files\n", new)
+	fmt.Fprintf(w, "├── Missing:  %d files\n", missing)
+	fmt.Fprintf(w, "├── Modified: %d files\n", differs)
+	fmt.Fprintf(w, "└── Errors:   %d files\n", errors)
+
+	return nil
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// CSVResultsFormatter emits one RFC 4180 row per comparison, for piping
+// check's output into a spreadsheet. reason, bytesRead, and duration are
+// left blank: FileComparison doesn't carry per-file comparison detail
+// today, only the final Status.
+type CSVResultsFormatter struct{}
+
+func NewCSVResultsFormatter() *CSVResultsFormatter {
+	return &CSVResultsFormatter{}
+}
+
+func (f *CSVResultsFormatter) FormatResults(w io.Writer, comparisons []*scan.FileComparison, progress *scan.Progress, cfg *config.Config) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"path", "status", "level", "size", "srcMTime", "tgtMTime", "reason", "bytesRead", "duration"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, comp := range comparisons {
+		row := []string{
+			comp.Path,
+			string(comp.Status),
+			string(comp.Level),
+			resultSize(comp),
+			resultModTime(comp.Source),
+			resultModTime(comp.Target),
+			"", // reason: not tracked per-comparison yet
+			"", // bytesRead: not tracked per-comparison yet
+			"", // duration: not tracked per-comparison yet
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func resultSize(comp *scan.FileComparison) string {
+	if comp.Source != nil {
+		return fmt.Sprintf("%d", comp.Source.Size)
+	}
+	if comp.Target != nil {
+		return fmt.Sprintf("%d", comp.Target.Size)
+	}
+	return ""
+}
+
+func resultModTime(fi *scan.FileInfo) string {
+	if fi == nil {
+		return ""
+	}
+	return time.Unix(fi.ModTime, 0).UTC().Format(time.RFC3339)
+}
+
+// statusLabel and statusColor give HTMLResultsFormatter a human-readable
+// name and a color class per scan.FileStatus.
+func statusLabel(status scan.FileStatus) string {
+	switch status {
+	case scan.StatusMatch:
+		return "match"
+	case scan.StatusNew:
+		return "new"
+	case scan.StatusMissing:
+		return "missing"
+	case scan.StatusDiffer:
+		return "differs"
+	case scan.StatusError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func statusColor(status scan.FileStatus) string {
+	switch status {
+	case scan.StatusMatch:
+		return "#2e7d32" // green
+	case scan.StatusNew:
+		return "#1565c0" // blue
+	case scan.StatusMissing:
+		return "#ef6c00" // orange
+	case scan.StatusDiffer:
+		return "#c62828" // red
+	case scan.StatusError:
+		return "#b71c1c" // dark red
+	default:
+		return "#616161" // gray
+	}
+}
+
+// HTMLResultsFormatter renders a self-contained HTML report: a sortable
+// table of every comparison, color-coded by status, with summary counts -
+// meant to be attached to a ticket or shared without any supporting files.
+type HTMLResultsFormatter struct{}
+
+func NewHTMLResultsFormatter() *HTMLResultsFormatter {
+	return &HTMLResultsFormatter{}
+}
+
+func (f *HTMLResultsFormatter) FormatResults(w io.Writer, comparisons []*scan.FileComparison, progress *scan.Progress, cfg *config.Config) error {
+	var matches, new, missing, differs, errors int
+	for _, comp := range comparisons {
+		switch comp.Status {
+		case scan.StatusMatch:
+			matches++
+		case scan.StatusNew:
+			new++
+		case scan.StatusMissing:
+			missing++
+		case scan.StatusDiffer:
+			differs++
+		case scan.StatusError:
+			errors++
+		}
+	}
+
+	fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>backup-butler check: %s -&gt; %s</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { cursor: pointer; background: #f0f0f0; }
+tr:nth-child(even) { background: #fafafa; }
+.status { font-weight: bold; }
+</style>
+<script>
+function sortTable(col) {
+  var table = document.getElementById("results");
+  var rows = Array.from(table.rows).slice(1);
+  var asc = table.dataset.sortCol == col && table.dataset.sortDir != "asc";
+  rows.sort(function(a, b) {
+    var x = a.cells[col].innerText, y = b.cells[col].innerText;
+    return asc ? x.localeCompare(y, undefined, {numeric: true}) : y.localeCompare(x, undefined, {numeric: true});
+  });
+  rows.forEach(function(r) { table.tBodies[0].appendChild(r); });
+  table.dataset.sortCol = col;
+  table.dataset.sortDir = asc ? "asc" : "desc";
+}
+</script>
+</head>
+<body>
+<h1>backup-butler check results</h1>
+<p>Source: %s<br>Target: %s</p>
+<h2>Summary</h2>
+<ul>
+<li>Matched: %d</li>
+<li>New: %d</li>
+<li>Missing: %d</li>
+<li>Modified: %d</li>
+<li>Errors: %d</li>
+<li>Scanned files: %d</li>
+<li>Total size: %s</li>
+</ul>
+<table id="results" data-sort-col="" data-sort-dir="">
+<thead><tr>
+<th onclick="sortTable(0)">Path</th>
+<th onclick="sortTable(1)">Status</th>
+<th onclick="sortTable(2)">Level</th>
+</tr></thead>
+<tbody>
+`,
+		html.EscapeString(cfg.Source), html.EscapeString(cfg.Target),
+		html.EscapeString(cfg.Source), html.EscapeString(cfg.Target),
+		matches, new, missing, differs, errors,
+		progress.ScannedFiles, formatBytes(progress.TotalBytes))
+
+	for _, comp := range comparisons {
+		fmt.Fprintf(w, "<tr><td>%s</td><td class=\"status\" style=\"color:%s\">%s</td><td>%s</td></tr>\n",
+			html.EscapeString(comp.Path),
+			statusColor(comp.Status),
+			statusLabel(comp.Status),
+			html.EscapeString(string(comp.Level)))
+	}
+
+	fmt.Fprintf(w, "</tbody>\n</table>\n</body>\n</html>\n")
+	return nil
+}
@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/domain/backup"
+	"github.com/jack-sneddon/backup-butler/internal/ui/termstatus"
+)
+
+// ewmaAlpha weights the newest throughput sample against the running
+// average, matching rclone's default bandwidth-smoothing constant.
+const ewmaAlpha = 0.3
+
+// activeStatus is the status block currently driving Message/Error, if
+// any. It's set by Reporter.Start implementations and cleared by Stop so a
+// concurrent log line never tears the status block mid-redraw.
+var (
+	activeStatusMu sync.Mutex
+	activeStatus   *termstatus.Status
+)
+
+// SetStatus registers (or, with nil, clears) the termstatus.Status that
+// Message and Error should route log lines through.
+func SetStatus(s *termstatus.Status) {
+	activeStatusMu.Lock()
+	defer activeStatusMu.Unlock()
+	activeStatus = s
+}
+
+// Message prints a log line, routing it above the live status block (if
+// one is active) instead of writing straight to stdout.
+func Message(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+
+	activeStatusMu.Lock()
+	s := activeStatus
+	activeStatusMu.Unlock()
+
+	if s != nil {
+		s.Message(line)
+		return
+	}
+	fmt.Println(line)
+}
+
+// Error prints an error line the same way Message does, colored like the
+// rest of this package's PrintError.
+func Error(format string, args ...interface{}) {
+	line := fmt.Sprintf("ERROR: %s", fmt.Sprintf(format, args...))
+
+	activeStatusMu.Lock()
+	s := activeStatus
+	activeStatusMu.Unlock()
+
+	if s != nil {
+		s.Message(ColorRed + line + ColorReset)
+		return
+	}
+	PrintColored(ColorRed, "%s\n", line)
+}
+
+// Reporter consumes Service.Backup's typed backup.Event stream and renders
+// it somehow - a live terminal status block, line-delimited JSON, or
+// anything else. Handle is called synchronously, once per Event in order,
+// from the goroutine draining the event channel, so it must not block.
+// Start/Stop bracket the run, for a Reporter that owns a resource (like
+// TerminalReporter's termstatus.Status) needing setup and teardown.
+type Reporter interface {
+	Start()
+	Handle(e backup.Event)
+	Stop()
+}
+
+// NewReporter picks the built-in Reporter for a run expected to process
+// filesTotal files totaling bytesTotal bytes: TerminalReporter by default,
+// or JSONReporter when jsonMode is set (the CLI's --output=json), so
+// scripted callers can consume progress without parsing ANSI.
+func NewReporter(filesTotal int, bytesTotal int64, jsonMode bool) Reporter {
+	if jsonMode {
+		return NewJSONReporter()
+	}
+	return NewTerminalReporter(filesTotal, bytesTotal)
+}
+
+// recentErrorLimit caps how many failed-file lines trail the status block,
+// so a run with many failures doesn't push the worker/throughput lines
+// scrolling off screen.
+const recentErrorLimit = 5
+
+// TerminalReporter renders a live status block for a running backup: one
+// line per worker's current file and the directory it's in, an aggregate
+// line with windowed throughput and an ETA derived from the scan phase's
+// total size, and - once any file has failed - a trailing handful of the
+// most recent failures.
+type TerminalReporter struct {
+	mu           sync.Mutex
+	status       *termstatus.Status
+	workers      map[int]string
+	filesDone    int
+	filesTotal   int
+	bytesDone    int64
+	bytesTotal   int64
+	rate         float64
+	lastTick     time.Time
+	recentErrors []string
+}
+
+// NewTerminalReporter creates a TerminalReporter for a run expected to
+// process filesTotal files totaling bytesTotal bytes.
+func NewTerminalReporter(filesTotal int, bytesTotal int64) *TerminalReporter {
+	return &TerminalReporter{
+		status:     termstatus.New(os.Stdout),
+		workers:    make(map[int]string),
+		filesTotal: filesTotal,
+		bytesTotal: bytesTotal,
+	}
+}
+
+// Start begins driving the display and makes this TerminalReporter the
+// target for Message/Error.
+func (r *TerminalReporter) Start() {
+	r.mu.Lock()
+	r.lastTick = time.Now()
+	r.mu.Unlock()
+
+	SetStatus(r.status)
+}
+
+// Stop tears down the status block and detaches it from Message/Error.
+func (r *TerminalReporter) Stop() {
+	SetStatus(nil)
+	r.status.Stop()
+}
+
+// Handle implements Reporter.
+func (r *TerminalReporter) Handle(e backup.Event) {
+	switch e.Type {
+	case backup.EventFileStarted:
+		r.mu.Lock()
+		r.workers[e.WorkerID] = e.Path
+		r.mu.Unlock()
+		r.render()
+
+	case backup.EventFileCompleted, backup.EventFileSkipped, backup.EventFileFailed:
+		r.mu.Lock()
+		delete(r.workers, e.WorkerID)
+		r.filesDone++
+		r.bytesDone += e.Bytes
+
+		now := time.Now()
+		if elapsed := now.Sub(r.lastTick).Seconds(); elapsed > 0 {
+			instant := float64(e.Bytes) / elapsed
+			r.rate = ewmaAlpha*instant + (1-ewmaAlpha)*r.rate
+		}
+		r.lastTick = now
+
+		if e.Type == backup.EventFileFailed {
+			r.recentErrors = append(r.recentErrors, fmt.Sprintf("%s: %v", e.Path, e.Err))
+			if len(r.recentErrors) > recentErrorLimit {
+				r.recentErrors = r.recentErrors[len(r.recentErrors)-recentErrorLimit:]
+			}
+		}
+		r.mu.Unlock()
+		r.render()
+	}
+}
+
+func (r *TerminalReporter) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workerIDs := make([]int, 0, len(r.workers))
+	for id := range r.workers {
+		workerIDs = append(workerIDs, id)
+	}
+	sort.Ints(workerIDs)
+
+	lines := make([]string, 0, len(workerIDs)+1+len(r.recentErrors))
+	for _, id := range workerIDs {
+		lines = append(lines, fmt.Sprintf("worker %d: %s", id, r.workers[id]))
+	}
+
+	var eta time.Duration
+	if remaining := r.bytesTotal - r.bytesDone; r.rate > 0 && remaining > 0 {
+		eta = (time.Duration(float64(remaining)/r.rate) * time.Second).Round(time.Second)
+	}
+
+	lines = append(lines, fmt.Sprintf("%d/%d files | %.2f MB/s | ETA %s | %d dir(s) in progress",
+		r.filesDone, r.filesTotal, r.rate/(1024*1024), eta, len(activeDirs(r.workers))))
+
+	if len(r.recentErrors) > 0 {
+		lines = append(lines, "recent errors:")
+		for _, e := range r.recentErrors {
+			lines = append(lines, "  "+e)
+		}
+	}
+
+	r.status.Update(lines)
+}
+
+// activeDirs returns the distinct parent directories of every worker's
+// current file, so render can report how many directories are being worked
+// concurrently without needing the scan phase's per-directory totals.
+func activeDirs(workers map[int]string) map[string]struct{} {
+	dirs := make(map[string]struct{}, len(workers))
+	for _, path := range workers {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	return dirs
+}
+
+// JSONReporter emits one JSON object per line to stdout per Event, for
+// scripted callers (monitoring tools, CI, a future web UI) that want to
+// consume backup progress without scraping terminal output.
+type JSONReporter struct{}
+
+// NewJSONReporter creates a JSONReporter.
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+// Start implements Reporter. JSONReporter has nothing to set up: it never
+// owns the terminal's status block, so Message/Error keep printing plain
+// lines while it's active.
+func (r *JSONReporter) Start() {}
+
+// Stop implements Reporter.
+func (r *JSONReporter) Stop() {}
+
+// Handle implements Reporter.
+func (r *JSONReporter) Handle(e backup.Event) {
+	ev := jsonEvent{
+		Type:       string(e.Type),
+		Worker:     e.WorkerID,
+		Path:       e.Path,
+		Bytes:      e.Bytes,
+		Checksum:   e.Checksum,
+		Error:      errString(e.Err),
+		FilesTotal: e.FilesTotal,
+		BytesTotal: e.BytesTotal,
+		Directory:  e.Directory,
+	}
+	if e.Type == backup.EventVersionComplete {
+		ev.Stats = &e.Stats
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// jsonEvent is the line-delimited JSON shape JSONReporter emits, one object
+// per backup.Event.
+type jsonEvent struct {
+	Type       string              `json:"type"`
+	Worker     int                 `json:"worker,omitempty"`
+	Path       string              `json:"path,omitempty"`
+	Bytes      int64               `json:"bytes,omitempty"`
+	Checksum   string              `json:"checksum,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	FilesTotal int                 `json:"files_total,omitempty"`
+	BytesTotal int64               `json:"bytes_total,omitempty"`
+	Directory  string              `json:"directory,omitempty"`
+	Stats      *backup.BackupStats `json:"stats,omitempty"`
+}
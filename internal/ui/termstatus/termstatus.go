@@ -0,0 +1,110 @@
+// Package termstatus renders a live, redrawn-in-place status block above a
+// scrolling log region, the way restic's internal/ui/termstatus keeps build
+// progress pinned to the bottom of the terminal while log lines scroll
+// above it. It falls back to plain, non-overwriting output when stdout
+// isn't a terminal, so the same calls work for both interactive use and
+// piped/CI output.
+package termstatus
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Status owns a writer and the status block currently drawn to it.
+type Status struct {
+	mu      sync.Mutex
+	out     io.Writer
+	isTTY   bool
+	current []string
+}
+
+// New creates a Status that draws to out.
+func New(out *os.File) *Status {
+	return &Status{
+		out:   out,
+		isTTY: isTerminal(out),
+	}
+}
+
+// isTerminal reports whether out is an interactive terminal rather than a
+// pipe or redirected file, without pulling in a terminal-detection
+// dependency for what's otherwise a single stat call.
+func isTerminal(out *os.File) bool {
+	info, err := out.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Update redraws the status block with lines, replacing whatever was drawn
+// before. When stdout isn't a terminal it just prints the newest line, so
+// non-interactive output stays readable instead of filling with escape
+// codes.
+func (s *Status) Update(lines []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isTTY {
+		if len(lines) > 0 {
+			fmt.Fprintln(s.out, lines[len(lines)-1])
+		}
+		s.current = lines
+		return
+	}
+
+	s.clear()
+	s.draw(lines)
+	s.current = lines
+}
+
+// Message prints a persisted log line above the status block, then redraws
+// the block below it, so scrolling log output and the live block never
+// tear each other apart.
+func (s *Status) Message(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isTTY {
+		fmt.Fprintln(s.out, line)
+		return
+	}
+
+	s.clear()
+	fmt.Fprintln(s.out, line)
+	s.draw(s.current)
+}
+
+// Stop clears the status block, leaving the cursor on its own line below
+// whatever was last printed.
+func (s *Status) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isTTY {
+		s.clear()
+	}
+	s.current = nil
+}
+
+// clear erases the previously drawn block without touching s.current, so
+// callers can redraw it (Update) or leave it empty (Stop).
+func (s *Status) clear() {
+	if len(s.current) == 0 {
+		return
+	}
+	fmt.Fprintf(s.out, "\033[%dA", len(s.current))
+	for range s.current {
+		fmt.Fprint(s.out, "\033[2K\n")
+	}
+	fmt.Fprintf(s.out, "\033[%dA", len(s.current))
+}
+
+func (s *Status) draw(lines []string) {
+	for _, line := range lines {
+		fmt.Fprint(s.out, "\033[2K", line, "\n")
+	}
+}
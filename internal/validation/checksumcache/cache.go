@@ -0,0 +1,106 @@
+// internal/validation/checksumcache/cache.go
+package checksumcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Entry is one cached digest: the file state it was computed against, so a
+// later lookup can tell whether the file has changed since.
+type Entry struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"` // unix seconds, matching scan.FileInfo.ModTime
+	Digest  string `json:"digest"`
+}
+
+// Cache is a persistent store of (path, algorithm) -> Entry, so a deep
+// validation pass doesn't re-hash a file whose size and mtime haven't
+// changed since the last run. It's safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]Entry
+}
+
+// FileName is the cache's conventional location under a target directory's
+// .backup-butler/ state folder.
+const FileName = "checksum-cache.json"
+
+func entryKey(algorithm, path string) string {
+	return algorithm + "|" + path
+}
+
+// New returns an empty Cache that persists to path.
+func New(path string) *Cache {
+	return &Cache{path: path, entries: make(map[string]Entry)}
+}
+
+// Load reads a previously saved Cache from path. A missing file is not an
+// error - it just means there's nothing cached yet.
+func Load(path string) (*Cache, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(path), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checksum cache %s: %w", path, err)
+	}
+
+	entries := make(map[string]Entry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse checksum cache %s: %w", path, err)
+	}
+	return &Cache{path: path, entries: entries}, nil
+}
+
+// Save writes the cache to disk as indented JSON, creating parent
+// directories as needed.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("create checksum cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checksum cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("write checksum cache %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// Get returns the cached digest for path under algorithm, provided size and
+// modTime still match what was recorded; otherwise the entry is stale (or
+// absent) and ok is false.
+func (c *Cache) Get(path string, size, modTime int64, algorithm string) (digest string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[entryKey(algorithm, path)]
+	if !found || entry.Size != size || entry.ModTime != modTime {
+		return "", false
+	}
+	return entry.Digest, true
+}
+
+// Put records digest as path's current checksum under algorithm, keyed to
+// the size/modTime it was computed from so a later change is detected
+// automatically. It's also how a prior backup's recorded
+// version.FileMetadata.Checksum can seed the cache on load, so a fresh run
+// against that backup pays zero hashing cost for unchanged files.
+func (c *Cache) Put(path string, size, modTime int64, algorithm, digest string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[entryKey(algorithm, path)] = Entry{Size: size, ModTime: modTime, Digest: digest}
+}
@@ -0,0 +1,144 @@
+// internal/validation/chunked.go
+package validation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/scan"
+	"github.com/jack-sneddon/backup-butler/internal/storage/chunker"
+	"github.com/jack-sneddon/backup-butler/internal/types"
+)
+
+// ChunkedValidator compares files by their content-defined chunk digests
+// rather than a whole-file hash, so a mismatch names the first chunk that
+// differs. Re-chunking reads the whole file; only a sidecar index whose
+// recorded size still matches lets chunksFor skip that read.
+type ChunkedValidator struct {
+	opts  *ValidatorOptions
+	chunk *chunker.Chunker
+}
+
+func NewChunkedValidator(opts *ValidatorOptions) *ChunkedValidator {
+	if opts == nil {
+		opts = &ValidatorOptions{
+			BufferSize: chunker.DefaultAverageSize,
+			Algorithm:  "sha256",
+		}
+	}
+	return &ChunkedValidator{
+		opts:  opts,
+		chunk: chunker.New(),
+	}
+}
+
+func (v *ChunkedValidator) Level() types.ValidationLevel {
+	return types.Chunked
+}
+
+func (v *ChunkedValidator) Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult {
+	start := time.Now()
+
+	if err := ctx.Err(); err != nil {
+		return canceledResult(start, 0)
+	}
+
+	// Size/mtime mismatches are still the cheapest signal that a file
+	// changed; only fall through to chunking when they agree.
+	quickResult := NewQuickValidator().Compare(ctx, source, target)
+	if !quickResult.Equal {
+		return quickResult
+	}
+
+	srcChunks, bytesRead, err := v.chunksFor(source.Path)
+	if err != nil {
+		return ComparisonResult{
+			Equal:     false,
+			Reason:    fmt.Sprintf("Error chunking source file: %v", err),
+			TimeTaken: time.Since(start),
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return canceledResult(start, bytesRead)
+	}
+
+	tgtChunks, tgtBytesRead, err := v.chunksFor(target.Path)
+	if err != nil {
+		return ComparisonResult{
+			Equal:     false,
+			Reason:    fmt.Sprintf("Error chunking target file: %v", err),
+			TimeTaken: time.Since(start),
+			BytesRead: bytesRead,
+		}
+	}
+	bytesRead += tgtBytesRead
+
+	var deduped int
+	if v.opts.BlockStore != nil {
+		for _, c := range tgtChunks {
+			if v.opts.BlockStore.Seen(c.Digest) {
+				deduped++
+			}
+			v.opts.BlockStore.Record(c.Digest)
+		}
+	}
+
+	equal, reason := compareChunkLists(srcChunks, tgtChunks)
+	return ComparisonResult{
+		Equal:         equal,
+		Reason:        reason,
+		TimeTaken:     time.Since(start),
+		BytesRead:     bytesRead,
+		DedupedChunks: deduped,
+	}
+}
+
+// chunksFor returns path's chunk digests, preferring a cached sidecar index
+// over re-chunking when the file's size hasn't changed since it was
+// indexed.
+func (v *ChunkedValidator) chunksFor(path string) ([]chunker.Chunk, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if v.opts.IndexDir != "" {
+		if idx, err := chunker.LoadIndex(v.opts.IndexDir, path); err == nil && idx != nil && idx.Size == info.Size() {
+			return idx.Chunks, 0, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	chunks := v.chunk.SplitBytes(data)
+
+	if v.opts.IndexDir != "" {
+		_ = chunker.SaveIndex(v.opts.IndexDir, path, &chunker.FileIndex{
+			Path:   path,
+			Size:   info.Size(),
+			Chunks: chunks,
+		})
+	}
+
+	return chunks, int64(len(data)), nil
+}
+
+func compareChunkLists(a, b []chunker.Chunk) (bool, string) {
+	if len(a) != len(b) {
+		return false, fmt.Sprintf("Chunk count differs (%d vs %d)", len(a), len(b))
+	}
+
+	for i := range a {
+		if a[i].Digest != b[i].Digest {
+			return false, fmt.Sprintf("Chunk %d digest mismatch", i)
+		}
+	}
+
+	return true, "Chunk digest match"
+}
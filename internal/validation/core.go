@@ -2,13 +2,16 @@
 package validation
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/logger"
 	"github.com/jack-sneddon/backup-butler/internal/scan"
+	"github.com/jack-sneddon/backup-butler/internal/storage/chunker"
 	"github.com/jack-sneddon/backup-butler/internal/types"
+	"github.com/jack-sneddon/backup-butler/internal/validation/checksumcache"
 )
 
 // CriticalPath defines special validation requirements for specific paths
@@ -39,16 +42,62 @@ var SupportedAlgorithms = map[string]bool{
 
 // ComparisonStrategy defines how files should be compared
 type ComparisonStrategy interface {
-	// Compare checks equality between source and target files
-	Compare(source, target *scan.FileInfo) ComparisonResult
+	// Compare checks equality between source and target files. Strategies
+	// that read file content check ctx between reads and return a
+	// ComparisonResult with Reason "canceled" as soon as ctx is done,
+	// rather than running a multi-gigabyte comparison to completion after
+	// the caller has stopped waiting for it.
+	Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult
 	// Level returns the comparison level used
 	Level() types.ValidationLevel
 }
 
+// canceledResult reports ctx's cancellation as a ComparisonResult, the
+// shared shape every strategy returns the moment it notices ctx is done.
+func canceledResult(start time.Time, bytesRead int64) ComparisonResult {
+	return ComparisonResult{
+		Equal:     false,
+		Reason:    "canceled",
+		TimeTaken: time.Since(start),
+		BytesRead: bytesRead,
+	}
+}
+
 // ValidatorOptions contains configuration for validators
 type ValidatorOptions struct {
 	BufferSize int    // Size of read buffer for content validation
 	Algorithm  string // Hash algorithm (e.g., "sha256")
+
+	// IndexDir is only used by the Chunked strategy: it holds the per-file
+	// chunk-digest sidecar indexes. CacheSize is carried here but no
+	// strategy reads it; ChunkedValidator keeps no in-memory chunk cache.
+	IndexDir  string
+	CacheSize int64
+
+	// BlockStore, if set, lets the Chunked strategy recognize a target
+	// chunk whose digest already exists elsewhere in the tree - a
+	// duplicated or moved file - and report it via
+	// ComparisonResult.DedupedChunks instead of silently re-verifying it as
+	// ordinary new content.
+	BlockStore *chunker.BlockStore
+
+	// Root and DeepVerifyPatterns are only used by the Wildcard strategy:
+	// Root is the source directory patterns are evaluated relative to.
+	Root               string
+	DeepVerifyPatterns []string
+
+	// Cache, if set, lets DeepValidator skip re-hashing a file whose size
+	// and mtime match a previously recorded digest. Rehash bypasses it,
+	// forcing every digest to be recomputed (and the cache refreshed).
+	Cache  *checksumcache.Cache
+	Rehash bool
+
+	// Progress, if set, is called periodically during DeepValidator's
+	// content-compare loop with the bytes read so far and the file's total
+	// size, letting a caller drive a live per-file progress display (e.g. a
+	// termstatus.Status) without DeepValidator knowing anything about how
+	// progress is rendered.
+	Progress func(path string, bytesRead, totalBytes int64)
 }
 
 // ComparisonResult contains the outcome of a comparison operation
@@ -57,6 +106,11 @@ type ComparisonResult struct {
 	Reason    string
 	TimeTaken time.Duration
 	BytesRead int64
+
+	// DedupedChunks counts target chunks that ChunkedValidator found
+	// already recorded in opts.BlockStore, i.e. content duplicated
+	// elsewhere in the tree. Zero for every other strategy.
+	DedupedChunks int
 }
 
 // ValidationResult combines comparison results with validation status
@@ -69,9 +123,19 @@ type ValidationResult struct {
 
 // FileValidator combines comparison and validation logic
 type FileValidator struct {
-	strategy ComparisonStrategy
-	rules    ValidationRules
-	stats    *ValidationStats
+	strategy      ComparisonStrategy
+	rules         ValidationRules
+	stats         *ValidationStats
+	criticalPaths *pathMatcher
+
+	// scheduleFreq, schedulePaths and scheduleExclude are compiled once
+	// from rules.ScheduledDeep; scheduleState is the (initially empty)
+	// per-path last-run record, loaded/saved explicitly via
+	// LoadScheduleState/SaveScheduleState.
+	scheduleFreq    *frequency
+	schedulePaths   *GlobSet
+	scheduleExclude *GlobSet
+	scheduleState   *ScheduleState
 }
 
 // ValidationRules defines integrity requirements
@@ -83,12 +147,21 @@ type ValidationRules struct {
 type CriticalPathRule struct {
 	Pattern string
 	Level   types.ValidationLevel
+
+	// CaseInsensitive makes Pattern match regardless of path case, for
+	// volumes (e.g. FAT32/exFAT, some network shares) that don't preserve
+	// case reliably.
+	CaseInsensitive bool
 }
 
+// ScheduledValidation periodically escalates to Deep on a frequency,
+// rather than only ever reacting to CriticalPaths. Frequency.Due is
+// evaluated per path against ScheduleState's persisted last-run times
+// rather than a single global timestamp, so different files can be on
+// different schedules.
 type ScheduledValidation struct {
 	Enabled   bool
 	Frequency string
-	LastRun   time.Time
 	Paths     []string
 	Exclude   []string
 }
@@ -98,8 +171,20 @@ type ValidationStats struct {
 	QuickChecks    int
 	StandardChecks int
 	DeepChecks     int
-	StartTime      time.Time
-	EndTime        time.Time
+	ChunkedChecks  int
+	WildcardChecks int
+	// CachedChecksums counts Deep comparisons resolved from the checksum
+	// cache instead of re-hashing both files.
+	CachedChecksums int
+	// ScheduledDeepChecks counts Deep comparisons triggered by
+	// ScheduledDeep's frequency, as opposed to a CriticalPaths rule.
+	ScheduledDeepChecks int
+	// DedupedChunks sums ComparisonResult.DedupedChunks across every
+	// Chunked comparison, the running count of chunks this run recognized
+	// as duplicates of content already seen elsewhere in the tree.
+	DedupedChunks int
+	StartTime     time.Time
+	EndTime       time.Time
 }
 
 func (opts *ValidatorOptions) Validate() error {
@@ -121,17 +206,32 @@ func (opts *ValidatorOptions) Validate() error {
 
 // NewFileValidator creates a new validator with specified strategy and rules
 func NewFileValidator(strategy ComparisonStrategy, rules ValidationRules) *FileValidator {
-	return &FileValidator{
-		strategy: strategy,
-		rules:    rules,
+	v := &FileValidator{
+		strategy:      strategy,
+		rules:         rules,
+		criticalPaths: newPathMatcher(rules.CriticalPaths),
+		scheduleState: NewScheduleState(),
 		stats: &ValidationStats{
 			StartTime: time.Now(),
 		},
 	}
+
+	if sched := rules.ScheduledDeep; sched != nil && sched.Enabled {
+		freq, err := parseFrequency(sched.Frequency)
+		if err != nil {
+			logger.Warn("Invalid ScheduledDeep frequency, scheduled deep validation disabled", "frequency", sched.Frequency, "error", err)
+		} else {
+			v.scheduleFreq = freq
+			v.schedulePaths = NewGlobSet(sched.Paths)
+			v.scheduleExclude = NewGlobSet(sched.Exclude)
+		}
+	}
+
+	return v
 }
 
 // Validate performs both comparison and validation
-func (v *FileValidator) Validate(source, target *scan.FileInfo) ValidationResult {
+func (v *FileValidator) Validate(ctx context.Context, source, target *scan.FileInfo) ValidationResult {
 	valLogger := logger.WithGroup("validator").With(
 		"source", source.Path,
 		"target", target.Path,
@@ -142,19 +242,21 @@ func (v *FileValidator) Validate(source, target *scan.FileInfo) ValidationResult
 	valLogger.Debug("Starting file validation")
 
 	// Determine appropriate comparison level based on rules
-	level := v.determineComparisonLevel(source.Path)
+	baseLevel := v.strategy.Level()
+	level, escalationSource := v.determineComparisonLevel(source.Path)
+	escalated := level != baseLevel
 
 	// If we need a different level than our current strategy, create it
-	if level != v.strategy.Level() {
+	if escalated {
 		valLogger.Info("Validation level escalated",
-			"from", v.strategy.Level(),
+			"from", baseLevel,
 			"to", level,
 		)
 		v.strategy = NewStrategy(level, nil) // Use default options
 	}
 
 	// Perform comparison
-	result := v.strategy.Compare(source, target)
+	result := v.strategy.Compare(ctx, source, target)
 	valLogger.Debug("Validation complete",
 		"equal", result.Equal,
 		"reason", result.Reason,
@@ -170,10 +272,25 @@ func (v *FileValidator) Validate(source, target *scan.FileInfo) ValidationResult
 		v.stats.StandardChecks++
 	case types.Deep:
 		v.stats.DeepChecks++
+		if result.Reason == cacheHitReason {
+			v.stats.CachedChecksums++
+		}
+		if escalationSource == escalationSourceSchedule {
+			v.stats.ScheduledDeepChecks++
+		}
+	case types.Chunked:
+		v.stats.ChunkedChecks++
+		v.stats.DedupedChunks += result.DedupedChunks
+	case types.Wildcard:
+		v.stats.WildcardChecks++
+	}
+
+	if escalationSource == escalationSourceSchedule {
+		v.scheduleState.recordRun(source.Path, time.Now())
 	}
 
 	// Validate against rules
-	messages := v.validateRules(source, result)
+	messages := v.validateRules(source, result, level, escalated)
 
 	return ValidationResult{
 		Comparison:  result,
@@ -183,39 +300,73 @@ func (v *FileValidator) Validate(source, target *scan.FileInfo) ValidationResult
 	}
 }
 
-func (v *FileValidator) determineComparisonLevel(path string) types.ValidationLevel {
+// Escalation sources reported by determineComparisonLevel, so Validate can
+// attribute stats and messages to the rule that actually fired.
+const (
+	escalationSourceCriticalPath = "critical-path"
+	escalationSourceSchedule     = "schedule"
+)
+
+func (v *FileValidator) determineComparisonLevel(path string) (level types.ValidationLevel, source string) {
+	level = v.strategy.Level()
+
+	if critical := v.getCriticalPathLevel(path); critical != "" && levelRank[critical] > levelRank[level] {
+		level = critical
+		source = escalationSourceCriticalPath
+	}
+
 	// Check scheduled deep validation
-	if v.shouldPerformScheduledDeep(path) {
-		return types.Deep
+	if v.shouldPerformScheduledDeep(path) && levelRank[types.Deep] > levelRank[level] {
+		level = types.Deep
+		source = escalationSourceSchedule
 	}
 
-	// Use strategy's default level
-	return v.strategy.Level()
+	return level, source
 }
 
-// shouldPerformScheduledDeep checks if the path needs scheduled deep validation
+// shouldPerformScheduledDeep reports whether path is due for a scheduled
+// Deep validation: ScheduledDeep must be enabled, path must match Paths
+// (or Paths is empty, meaning everything) and not match Exclude, and the
+// configured Frequency must judge the path's last recorded run as due.
 func (v *FileValidator) shouldPerformScheduledDeep(path string) bool {
-	if v.rules.ScheduledDeep == nil || !v.rules.ScheduledDeep.Enabled {
+	if v.scheduleFreq == nil {
+		return false
+	}
+	if v.schedulePaths != nil && len(v.rules.ScheduledDeep.Paths) > 0 && !v.schedulePaths.MatchAny(path) {
+		return false
+	}
+	if v.scheduleExclude != nil && v.scheduleExclude.MatchAny(path) {
 		return false
 	}
 
-	// Check if it's time for deep validation based on frequency and last run
-	// Implementation depends on frequency format (daily, weekly, monthly)
-	// For now, return false as placeholder
-	return false
+	return v.scheduleFreq.Due(v.scheduleState.lastRun(path), time.Now())
 }
 
-// getCriticalPathLevel checks if path matches any critical path patterns
+// getCriticalPathLevel checks if path matches any critical path patterns,
+// returning the strictest level among the rules that match, or "" if none
+// do.
 func (v *FileValidator) getCriticalPathLevel(path string) types.ValidationLevel {
-	// Implementation would use path matching against rules.CriticalPaths
-	// For now, return empty as placeholder
-	return ""
+	if v.criticalPaths == nil {
+		return ""
+	}
+	return v.criticalPaths.LevelFor(path)
 }
 
-// validateRules checks if the comparison result satisfies all validation rules
-func (v *FileValidator) validateRules(source *scan.FileInfo, result ComparisonResult) []string {
+// validateRules checks if the comparison result satisfies all validation
+// rules, returning a message for anything worth surfacing to the caller:
+// an escalation that fired for this file, or a critical path whose
+// comparison came back unequal.
+func (v *FileValidator) validateRules(source *scan.FileInfo, result ComparisonResult, level types.ValidationLevel, escalated bool) []string {
 	var messages []string
-	// Implementation would check various rules and collect validation messages
+
+	if escalated {
+		messages = append(messages, fmt.Sprintf("validation escalated to %s for %s", level, source.Path))
+	}
+
+	if critical := v.getCriticalPathLevel(source.Path); critical != "" && !result.Equal {
+		messages = append(messages, fmt.Sprintf("critical path mismatch at %s: %s", source.Path, result.Reason))
+	}
+
 	return messages
 }
 
@@ -234,6 +385,10 @@ func NewStrategy(level types.ValidationLevel, opts *ValidatorOptions) Comparison
 		return NewStandardValidator(opts)
 	case types.Deep:
 		return NewDeepValidator(opts)
+	case types.Chunked:
+		return NewChunkedValidator(opts)
+	case types.Wildcard:
+		return NewWildcardValidator(opts)
 	default:
 		panic(fmt.Sprintf("unsupported comparison level: %s", level))
 	}
@@ -2,6 +2,7 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,10 @@ import (
 	"github.com/jack-sneddon/backup-butler/internal/types"
 )
 
+// cacheHitReason is the Reason reported when both sides of a Deep
+// comparison resolved from the checksum cache instead of being re-hashed.
+const cacheHitReason = "cache-hit"
+
 // DeepValidator implements full content comparison
 type DeepValidator struct {
 	opts *ValidatorOptions
@@ -30,11 +35,15 @@ func (v *DeepValidator) Level() types.ValidationLevel {
 	return types.Deep
 }
 
-func (v *DeepValidator) Compare(source, target *scan.FileInfo) ComparisonResult {
+func (v *DeepValidator) Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult {
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		return canceledResult(start, 0)
+	}
+
 	// First do quick comparison
-	quickResult := NewQuickValidator().Compare(source, target)
+	quickResult := NewQuickValidator().Compare(ctx, source, target)
 	if !quickResult.Equal {
 		return quickResult
 	}
@@ -43,11 +52,15 @@ func (v *DeepValidator) Compare(source, target *scan.FileInfo) ComparisonResult
 	standardResult := NewStandardValidator(&ValidatorOptions{
 		BufferSize: v.opts.BufferSize,
 		Algorithm:  v.opts.Algorithm,
-	}).Compare(source, target)
+	}).Compare(ctx, source, target)
 	if !standardResult.Equal {
 		return standardResult
 	}
 
+	if v.opts.Cache != nil {
+		return v.compareViaCache(source, target, start)
+	}
+
 	// Open both files
 	srcFile, err := os.Open(source.Path)
 	if err != nil {
@@ -75,10 +88,18 @@ func (v *DeepValidator) Compare(source, target *scan.FileInfo) ComparisonResult
 	var bytesRead int64
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return canceledResult(start, bytesRead)
+		}
+
 		srcN, srcErr := srcFile.Read(srcBuf)
 		tgtN, tgtErr := tgtFile.Read(tgtBuf)
 		bytesRead += int64(srcN)
 
+		if v.opts.Progress != nil {
+			v.opts.Progress(source.Path, bytesRead, source.Size)
+		}
+
 		// Check for read errors
 		if srcErr != nil && srcErr != io.EOF {
 			return ComparisonResult{
@@ -139,6 +160,60 @@ func (v *DeepValidator) Compare(source, target *scan.FileInfo) ComparisonResult
 	}
 }
 
+// compareViaCache compares source and target by full-file digest, reusing
+// v.opts.Cache wherever the cached digest's size/mtime still match rather
+// than re-reading the file. If both sides come from the cache, the result
+// is reported with BytesRead=0 and Reason=cacheHitReason so the caller can
+// tell an (almost) free comparison from one that actually hashed anything.
+func (v *DeepValidator) compareViaCache(source, target *scan.FileInfo, start time.Time) ComparisonResult {
+	srcDigest, srcHit, srcBytes, err := v.digest(source)
+	if err != nil {
+		return ComparisonResult{Equal: false, Reason: fmt.Sprintf("Error hashing source file: %v", err), TimeTaken: time.Since(start)}
+	}
+
+	tgtDigest, tgtHit, tgtBytes, err := v.digest(target)
+	if err != nil {
+		return ComparisonResult{Equal: false, Reason: fmt.Sprintf("Error hashing target file: %v", err), TimeTaken: time.Since(start), BytesRead: srcBytes}
+	}
+
+	equal := srcDigest == tgtDigest
+	reason := "Full content match (digest)"
+	if !equal {
+		reason = "Content differs (digest)"
+	}
+	if srcHit && tgtHit {
+		reason = cacheHitReason
+	}
+
+	return ComparisonResult{
+		Equal:     equal,
+		Reason:    reason,
+		TimeTaken: time.Since(start),
+		BytesRead: srcBytes + tgtBytes,
+	}
+}
+
+// digest returns fi's checksum, consulting v.opts.Cache first unless Rehash
+// is set. A cache miss (or a rehash) computes the digest from disk and
+// records it back to the cache, keyed to fi's current size/mtime.
+func (v *DeepValidator) digest(fi *scan.FileInfo) (sum string, hit bool, bytesRead int64, err error) {
+	algorithm := v.opts.Algorithm
+
+	if !v.opts.Rehash {
+		if cached, ok := v.opts.Cache.Get(fi.Path, fi.Size, fi.ModTime, algorithm); ok {
+			return cached, true, 0, nil
+		}
+	}
+
+	sum, bytesRead, err = fullChecksum(fi.Path)
+	if err != nil {
+		return "", false, bytesRead, err
+	}
+
+	v.opts.Cache.Put(fi.Path, fi.Size, fi.ModTime, algorithm, sum)
+	return sum, false, bytesRead, nil
+}
+
 // bytesEqual performs a constant-time comparison of two byte slices
 func bytesEqual(a, b []byte) bool {
 	if len(a) != len(b) {
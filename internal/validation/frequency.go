@@ -0,0 +1,187 @@
+// internal/validation/frequency.go
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frequency decides whether enough time has passed since a path's last
+// scheduled deep validation, from a ScheduledValidation.Frequency string:
+// either a Go duration ("168h"), a keyword ("daily", "weekly", "monthly"),
+// or a 5-field cron expression ("0 3 * * 0").
+type frequency struct {
+	raw      string
+	interval time.Duration // used unless cron is set
+	cron     *cronSchedule
+}
+
+var frequencyKeywords = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+}
+
+// parseFrequency parses s as a duration, keyword, or cron expression. A
+// cron expression is recognized by containing whitespace (five fields);
+// everything else is tried as a keyword, then as a time.ParseDuration
+// string.
+func parseFrequency(s string) (*frequency, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty frequency")
+	}
+
+	if fields := strings.Fields(s); len(fields) == 5 {
+		cs, err := parseCronSchedule(fields)
+		if err != nil {
+			return nil, fmt.Errorf("parse cron frequency %q: %w", s, err)
+		}
+		return &frequency{raw: s, cron: cs}, nil
+	}
+
+	if d, ok := frequencyKeywords[strings.ToLower(s)]; ok {
+		return &frequency{raw: s, interval: d}, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse frequency %q: not a duration, keyword, or 5-field cron expression", s)
+	}
+	return &frequency{raw: s, interval: d}, nil
+}
+
+// Due reports whether a deep validation is due, given the last time one ran
+// for this path (zero if it never has) and the current time.
+func (f *frequency) Due(lastRun, now time.Time) bool {
+	if lastRun.IsZero() {
+		return true
+	}
+	if f.cron != nil {
+		return f.cron.Due(lastRun, now)
+	}
+	return now.Sub(lastRun) >= f.interval
+}
+
+// cronSchedule is a 5-field (minute hour dom month dow) cron expression,
+// compiled once into the set of values each field allows. It supports `*`,
+// `*/N` steps, `a-b` ranges (with an optional `/N` step), and comma-joined
+// lists of any of those - the common subset most schedules need, not every
+// vixie-cron extension.
+type cronSchedule struct {
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool // 1-31
+	month  [13]bool // 1-12
+	dow    [7]bool  // 0-6, Sunday = 0
+
+	domRestricted bool
+	dowRestricted bool
+}
+
+func parseCronSchedule(fields []string) (*cronSchedule, error) {
+	cs := &cronSchedule{}
+
+	if err := parseCronField(fields[0], 0, 59, cs.minute[:]); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if err := parseCronField(fields[1], 0, 23, cs.hour[:]); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if err := parseCronField(fields[2], 1, 31, cs.dom[:]); err != nil {
+		return nil, fmt.Errorf("day-of-month: %w", err)
+	}
+	if err := parseCronField(fields[3], 1, 12, cs.month[:]); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if err := parseCronField(fields[4], 0, 6, cs.dow[:]); err != nil {
+		return nil, fmt.Errorf("day-of-week: %w", err)
+	}
+
+	cs.domRestricted = fields[2] != "*"
+	cs.dowRestricted = fields[4] != "*"
+	return cs, nil
+}
+
+// parseCronField fills allowed[v] = true for every value v in field, which
+// is one of `*`, `*/step`, `a-b`, `a-b/step`, `v`, or a comma-joined list of
+// those, each within [min, max].
+func parseCronField(field string, min, max int, allowed []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		base, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			base = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		switch {
+		case base == "*":
+			// lo/hi already cover the full range
+		case strings.Contains(base, "-"):
+			bounds := strings.SplitN(base, "-", 2)
+			a, err1 := strconv.Atoi(bounds[0])
+			b, err2 := strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || a > b {
+				return fmt.Errorf("invalid range %q", base)
+			}
+			lo, hi = a, b
+		default:
+			v, err := strconv.Atoi(base)
+			if err != nil {
+				return fmt.Errorf("invalid value %q", base)
+			}
+			lo, hi = v, v
+		}
+
+		if lo < min || hi > max {
+			return fmt.Errorf("value out of range [%d,%d] in %q", min, max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			allowed[v] = true
+		}
+	}
+	return nil
+}
+
+// Due reports whether at least one minute matching cs occurred in
+// (lastRun, now], checked minute by minute - cheap as long as the gap since
+// lastRun stays in the minutes-to-weeks range any realistic schedule has.
+func (cs *cronSchedule) Due(lastRun, now time.Time) bool {
+	t := lastRun.Truncate(time.Minute).Add(time.Minute)
+	for !t.After(now) {
+		if cs.matches(t) {
+			return true
+		}
+		t = t.Add(time.Minute)
+	}
+	return false
+}
+
+func (cs *cronSchedule) matches(t time.Time) bool {
+	if !cs.minute[t.Minute()] || !cs.hour[t.Hour()] || !cs.month[int(t.Month())] {
+		return false
+	}
+
+	domMatch := cs.dom[t.Day()]
+	dowMatch := cs.dow[int(t.Weekday())]
+
+	switch {
+	case cs.domRestricted && cs.dowRestricted:
+		// Standard cron semantics: either field matching is enough.
+		return domMatch || dowMatch
+	case cs.domRestricted:
+		return domMatch
+	case cs.dowRestricted:
+		return dowMatch
+	default:
+		return true
+	}
+}
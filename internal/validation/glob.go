@@ -0,0 +1,87 @@
+// internal/validation/glob.go
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// globPattern is a precompiled glob, supporting `**` (match any number of
+// path segments) in addition to the single-segment `*`/`?`/`[...]` that
+// path.Match already understands.
+type globPattern struct {
+	raw string
+	re  *regexp.Regexp
+}
+
+func compileGlob(pattern string) *globPattern {
+	return &globPattern{raw: pattern, re: regexp.MustCompile(globToRegexp(pattern))}
+}
+
+func (g *globPattern) Match(relPath string) bool {
+	return g.re.MatchString(relPath)
+}
+
+// globToRegexp translates a shell-style glob (with `**`) into an anchored
+// regexp. `**/` becomes `(?:.*/)?` (zero or more whole segments), any other
+// `**` becomes `.*`, a lone `*` becomes `[^/]*`, and everything else is
+// quoted literally.
+func globToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				// "**/" matches zero or more whole segments, so "**/foo"
+				// matches "foo" at the root but not "xfoo".
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					sb.WriteString("(?:.*/)?")
+					i++
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '.', '+', '(', ')', '|', '^', '$', '{', '}':
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			sb.WriteRune(runes[i])
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// GlobSet is a precompiled collection of glob patterns used to decide
+// whether a relative path should be deep-verified.
+type GlobSet struct {
+	patterns []*globPattern
+}
+
+// NewGlobSet compiles patterns once so repeated MatchAny calls (one per
+// file during a backup run) don't re-parse the glob each time.
+func NewGlobSet(patterns []string) *GlobSet {
+	gs := &GlobSet{}
+	for _, p := range patterns {
+		gs.patterns = append(gs.patterns, compileGlob(p))
+	}
+	return gs
+}
+
+// MatchAny reports whether relPath matches any pattern in the set.
+func (gs *GlobSet) MatchAny(relPath string) bool {
+	for _, p := range gs.patterns {
+		if p.Match(relPath) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// internal/validation/pathmatcher.go
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jack-sneddon/backup-butler/internal/types"
+)
+
+// levelRank orders ValidationLevel roughly by cost/thoroughness, so
+// pathMatcher can pick the strictest level among several matching rules.
+var levelRank = map[types.ValidationLevel]int{
+	types.Quick:    0,
+	types.Standard: 1,
+	types.Deep:     2,
+	types.Chunked:  3,
+	types.Wildcard: 4,
+}
+
+// compiledCriticalPath is a CriticalPathRule with its pattern precompiled to
+// a regexp, so matching a file against it during a run is just a
+// MatchString call.
+type compiledCriticalPath struct {
+	re    *regexp.Regexp
+	level types.ValidationLevel
+}
+
+// pathMatcher compiles a ValidationRules.CriticalPaths list once and reuses
+// it across every FileValidator.Validate call, rather than re-parsing each
+// rule's glob pattern per file.
+type pathMatcher struct {
+	rules []compiledCriticalPath
+}
+
+// newPathMatcher compiles rules into a pathMatcher. Each rule's Pattern
+// supports the same double-star glob syntax as the Wildcard strategy (e.g.
+// `**/photos/**/*.raw`, `backups/2024/**`); CaseInsensitive makes that rule
+// match regardless of path case.
+func newPathMatcher(rules []CriticalPathRule) *pathMatcher {
+	pm := &pathMatcher{}
+	for _, rule := range rules {
+		expr := globToRegexp(rule.Pattern)
+		if rule.CaseInsensitive {
+			expr = "(?i)" + expr
+		}
+		pm.rules = append(pm.rules, compiledCriticalPath{
+			re:    regexp.MustCompile(expr),
+			level: rule.Level,
+		})
+	}
+	return pm
+}
+
+// LevelFor returns the strictest level among every critical-path rule that
+// matches relPath, or "" if none do.
+func (pm *pathMatcher) LevelFor(relPath string) types.ValidationLevel {
+	relPath = strings.TrimPrefix(strings.ReplaceAll(relPath, "\\", "/"), "/")
+
+	var best types.ValidationLevel
+	for _, rule := range pm.rules {
+		if !rule.re.MatchString(relPath) {
+			continue
+		}
+		if best == "" || levelRank[rule.level] > levelRank[best] {
+			best = rule.level
+		}
+	}
+	return best
+}
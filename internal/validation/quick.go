@@ -2,6 +2,7 @@
 package validation
 
 import (
+	"context"
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/scan"
@@ -19,8 +20,9 @@ func (v *QuickValidator) Level() types.ValidationLevel {
 	return types.Quick
 }
 
-// Compare checks equality between source and target files using metadata only
-func (v *QuickValidator) Compare(source, target *scan.FileInfo) ComparisonResult {
+// Compare checks equality between source and target files using metadata
+// only - cheap enough that it doesn't check ctx.
+func (v *QuickValidator) Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult {
 	start := time.Now()
 
 	// Basic size comparison
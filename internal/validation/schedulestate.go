@@ -0,0 +1,112 @@
+// internal/validation/schedulestate.go
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ScheduleState is the per-path last-run record for scheduled deep
+// validation, persisted to disk so "weekly" or a cron expression can be
+// evaluated against when a path was last deep-validated rather than a
+// single global timestamp.
+type ScheduleState struct {
+	Runs map[string]time.Time `json:"runs"`
+}
+
+// ScheduleStateFileName is the conventional location under a target
+// directory's .backup-butler/ state folder.
+const ScheduleStateFileName = "deep-schedule.json"
+
+// NewScheduleState returns an empty ScheduleState.
+func NewScheduleState() *ScheduleState {
+	return &ScheduleState{Runs: make(map[string]time.Time)}
+}
+
+// LoadScheduleState reads a previously saved ScheduleState from path. A
+// missing file is not an error - it just means no path has ever run.
+func LoadScheduleState(path string) (*ScheduleState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewScheduleState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schedule state %s: %w", path, err)
+	}
+
+	st := NewScheduleState()
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parse schedule state %s: %w", path, err)
+	}
+	if st.Runs == nil {
+		st.Runs = make(map[string]time.Time)
+	}
+	return st, nil
+}
+
+// Save writes st to path as indented JSON via a temp-file-then-rename, so a
+// crash mid-write can't leave a half-written, unparsable state file behind.
+func (st *ScheduleState) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create schedule state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode schedule state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".deep-schedule-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp schedule state file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp schedule state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp schedule state file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename schedule state into place: %w", err)
+	}
+	return nil
+}
+
+// lastRun returns the last recorded run time for path, or the zero time if
+// it has never run.
+func (st *ScheduleState) lastRun(path string) time.Time {
+	return st.Runs[path]
+}
+
+// recordRun records that path's scheduled deep validation ran at t.
+func (st *ScheduleState) recordRun(path string, t time.Time) {
+	st.Runs[path] = t
+}
+
+// LoadScheduleState loads a previously saved ScheduleState into v, so
+// shouldPerformScheduledDeep can consult real history instead of treating
+// every path as never having run.
+func (v *FileValidator) LoadScheduleState(path string) error {
+	st, err := LoadScheduleState(path)
+	if err != nil {
+		return err
+	}
+	v.scheduleState = st
+	return nil
+}
+
+// SaveScheduleState persists the ScheduleState accumulated by Validate
+// calls so far to path, for the next run to diff against.
+func (v *FileValidator) SaveScheduleState(path string) error {
+	return v.scheduleState.Save(path)
+}
@@ -2,6 +2,7 @@
 package validation
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -34,11 +35,15 @@ func (v *StandardValidator) Level() types.ValidationLevel {
 	return types.Standard
 }
 
-func (v *StandardValidator) Compare(source, target *scan.FileInfo) ComparisonResult {
+func (v *StandardValidator) Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult {
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		return canceledResult(start, 0)
+	}
+
 	// First do quick comparison
-	quickResult := NewQuickValidator().Compare(source, target)
+	quickResult := NewQuickValidator().Compare(ctx, source, target)
 	if !quickResult.Equal {
 		return quickResult
 	}
@@ -0,0 +1,110 @@
+// internal/validation/wildcard.go
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/scan"
+	"github.com/jack-sneddon/backup-butler/internal/types"
+)
+
+// WildcardValidator applies a full SHA-256 comparison to files matching any
+// of opts.DeepVerifyPatterns (e.g. `**/*.raw`, `photos/2023/**`) and falls
+// back to StandardValidator's cheap 32KB probe for everything else, so a
+// single run can say "verify my RAW photos properly, spot-check the rest".
+type WildcardValidator struct {
+	opts     *ValidatorOptions
+	patterns *GlobSet
+}
+
+func NewWildcardValidator(opts *ValidatorOptions) *WildcardValidator {
+	if opts == nil {
+		opts = &ValidatorOptions{
+			BufferSize: 32768,
+			Algorithm:  "sha256",
+		}
+	}
+	return &WildcardValidator{
+		opts:     opts,
+		patterns: NewGlobSet(opts.DeepVerifyPatterns),
+	}
+}
+
+func (v *WildcardValidator) Level() types.ValidationLevel {
+	return types.Wildcard
+}
+
+func (v *WildcardValidator) Compare(ctx context.Context, source, target *scan.FileInfo) ComparisonResult {
+	if !v.matches(source.Path) {
+		return NewStandardValidator(v.opts).Compare(ctx, source, target)
+	}
+
+	start := time.Now()
+
+	if err := ctx.Err(); err != nil {
+		return canceledResult(start, 0)
+	}
+
+	quickResult := NewQuickValidator().Compare(ctx, source, target)
+	if !quickResult.Equal {
+		return quickResult
+	}
+
+	srcSum, srcBytes, err := fullChecksum(source.Path)
+	if err != nil {
+		return ComparisonResult{Equal: false, Reason: fmt.Sprintf("Error hashing source file: %v", err), TimeTaken: time.Since(start)}
+	}
+
+	tgtSum, tgtBytes, err := fullChecksum(target.Path)
+	if err != nil {
+		return ComparisonResult{Equal: false, Reason: fmt.Sprintf("Error hashing target file: %v", err), TimeTaken: time.Since(start), BytesRead: srcBytes}
+	}
+
+	return ComparisonResult{
+		Equal:     srcSum == tgtSum,
+		Reason:    v.reason(srcSum == tgtSum),
+		TimeTaken: time.Since(start),
+		BytesRead: srcBytes + tgtBytes,
+	}
+}
+
+func (v *WildcardValidator) matches(path string) bool {
+	if v.opts.Root == "" {
+		return v.patterns.MatchAny(path)
+	}
+
+	relPath, err := filepath.Rel(v.opts.Root, path)
+	if err != nil {
+		return v.patterns.MatchAny(path)
+	}
+	return v.patterns.MatchAny(filepath.ToSlash(relPath))
+}
+
+func (v *WildcardValidator) reason(equal bool) string {
+	if equal {
+		return "Content match (full hash, deep-verify pattern)"
+	}
+	return "Content differs (full hash, deep-verify pattern)"
+}
+
+func fullChecksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", n, err
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), n, nil
+}
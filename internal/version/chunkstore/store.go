@@ -0,0 +1,314 @@
+// Package chunkstore is a content-addressed, reference-counted store for the
+// chunks version.Manager splits backed-up files into, the way restic's
+// repack and borg's segment store let successive backups of mostly
+// unchanged files share storage instead of each version keeping its own
+// full copy.
+package chunkstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/jack-sneddon/backup-butler/internal/storage/chunker"
+)
+
+// Chunk sizing targets durable cross-version storage rather than the
+// short-lived diff window storage/chunker uses for delta-copy, hence the
+// larger max: 512KB min, 1MB average (a 20-bit cut mask), 8MB max.
+const (
+	MinSize     = 512 * 1024
+	AverageSize = 1024 * 1024
+	MaxSize     = 8 * 1024 * 1024
+)
+
+// ChunkRef locates one chunk within a reassembled file: which chunk (by
+// content hash) and where it belongs in the file. A BackupVersion's
+// FileChange carries a ChunkRef slice per path, making the version file a
+// manifest instead of a record of a whole-file copy.
+type ChunkRef struct {
+	Hash   string `json:"hash"`
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+}
+
+// Params reports the chunk sizing Store.Put used to split file content.
+// FileMetadata records it alongside a file's ChunkRef manifest so that if
+// MinSize/AverageSize/MaxSize are ever retuned, Reassemble still knows
+// these chunks were cut under the old parameters rather than silently
+// assuming the current constants applied.
+type Params struct {
+	MinSize     int `json:"min_size"`
+	AverageSize int `json:"average_size"`
+	MaxSize     int `json:"max_size"`
+}
+
+// CurrentParams returns the sizing this Store's Put calls use.
+func CurrentParams() Params {
+	return Params{MinSize: MinSize, AverageSize: AverageSize, MaxSize: MaxSize}
+}
+
+// Store is a content-addressed directory of chunks, sharded two hex
+// characters deep (the layout git uses for loose objects) so no single
+// directory ends up holding more entries than the filesystem handles well.
+type Store struct {
+	baseDir string
+
+	mu       sync.Mutex
+	refcount map[string]int
+}
+
+// Open loads (or creates) the chunk store rooted at baseDir/.versions/chunks.
+func Open(baseDir string) (*Store, error) {
+	dir := filepath.Join(baseDir, ".versions", "chunks")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create chunk store directory: %w", err)
+	}
+
+	s := &Store{baseDir: baseDir, refcount: make(map[string]int)}
+	if err := s.loadRefcounts(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) refcountPath() string {
+	return filepath.Join(s.baseDir, ".versions", "chunks", "refcounts.json")
+}
+
+func (s *Store) chunkPath(hash string) string {
+	return filepath.Join(s.baseDir, ".versions", "chunks", hash[:2], hash)
+}
+
+func (s *Store) loadRefcounts() error {
+	data, err := os.ReadFile(s.refcountPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read chunk refcounts: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.refcount); err != nil {
+		return fmt.Errorf("failed to parse chunk refcounts: %w", err)
+	}
+	return nil
+}
+
+// saveRefcounts writes via a temp file plus rename so a crash mid-write
+// can't corrupt the refcount table out from under a later GC pass.
+func (s *Store) saveRefcounts() error {
+	data, err := json.MarshalIndent(s.refcount, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal chunk refcounts: %w", err)
+	}
+
+	tmp := s.refcountPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write chunk refcounts: %w", err)
+	}
+	if err := os.Rename(tmp, s.refcountPath()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save chunk refcounts: %w", err)
+	}
+	return nil
+}
+
+// Put splits data into content-defined chunks, writes any not already
+// present to disk, and bumps every referenced chunk's refcount - including
+// chunks it didn't have to write because an earlier file already stored
+// that exact content. It returns the manifest a BackupVersion records to
+// later reassemble the file.
+func (s *Store) Put(data []byte) ([]ChunkRef, error) {
+	ck := chunker.NewWithSizes(MinSize, AverageSize, MaxSize)
+	chunks := ck.SplitBytes(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refs := make([]ChunkRef, 0, len(chunks))
+	for _, c := range chunks {
+		if _, exists := s.refcount[c.Digest]; !exists {
+			path := s.chunkPath(c.Digest)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create chunk shard directory: %w", err)
+			}
+			if err := os.WriteFile(path, data[c.Offset:c.Offset+c.Size], 0644); err != nil {
+				return nil, fmt.Errorf("failed to write chunk %s: %w", c.Digest, err)
+			}
+		}
+		s.refcount[c.Digest]++
+		refs = append(refs, ChunkRef{Hash: c.Digest, Offset: c.Offset, Length: c.Size})
+	}
+
+	if err := s.saveRefcounts(); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// Reuse bumps the refcount for a chunk manifest that's being cited again
+// without going through Put - the unchanged-file case, where a backup skips
+// recopying but the version still references the prior chunks.
+func (s *Store) Reuse(refs []ChunkRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ref := range refs {
+		s.refcount[ref.Hash]++
+	}
+	return s.saveRefcounts()
+}
+
+// Release decrements the refcount for each chunk in refs, deleting any
+// chunk whose count drops to zero. Called when a version's manifest is
+// removed (by cleanupOldVersions or Prune) so chunks no surviving version
+// still cites are reclaimed.
+func (s *Store) Release(refs []ChunkRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ref := range refs {
+		if s.refcount[ref.Hash] <= 1 {
+			delete(s.refcount, ref.Hash)
+			if err := os.Remove(s.chunkPath(ref.Hash)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove unreferenced chunk %s: %w", ref.Hash, err)
+			}
+			continue
+		}
+		s.refcount[ref.Hash]--
+	}
+	return s.saveRefcounts()
+}
+
+// Reassemble concatenates the chunks in refs, in the order given, back into
+// the original file content. Callers pass refs in the order Put returned
+// them (by Offset), so the concatenation reproduces the source byte order.
+func (s *Store) Reassemble(refs []ChunkRef) ([]byte, error) {
+	var total int64
+	for _, ref := range refs {
+		total += ref.Length
+	}
+
+	out := make([]byte, 0, total)
+	for _, ref := range refs {
+		data, err := os.ReadFile(s.chunkPath(ref.Hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chunk %s: %w", ref.Hash, err)
+		}
+		out = append(out, data...)
+	}
+	return out, nil
+}
+
+// VerifyReport summarizes a VerifyDeep pass over the chunk store.
+type VerifyReport struct {
+	ChunksChecked  int
+	CorruptChunks  []string // hash no longer matches its own content
+	MissingChunks  []string // refcounted but absent from disk
+	OrphanedChunks []string // present on disk but cited by no live version
+	BytesReclaimed int64
+}
+
+// GC removes every chunk not present in referenced, the same reference test
+// VerifyDeep applies, but without re-hashing surviving chunks against their
+// digest - a much cheaper pass for routine space reclamation, as opposed to
+// VerifyDeep's corruption-detecting one. In dryRun mode it reports what
+// would be removed without deleting anything or touching refcounts.
+func (s *Store) GC(referenced map[string]bool, dryRun bool) (VerifyReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var report VerifyReport
+
+	for hash := range s.refcount {
+		if referenced[hash] {
+			continue
+		}
+
+		path := s.chunkPath(hash)
+		if info, err := os.Stat(path); err == nil {
+			report.BytesReclaimed += info.Size()
+		} else if !os.IsNotExist(err) {
+			return report, fmt.Errorf("failed to stat chunk %s: %w", hash, err)
+		}
+		report.OrphanedChunks = append(report.OrphanedChunks, hash)
+
+		if dryRun {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return report, fmt.Errorf("failed to remove unreferenced chunk %s: %w", hash, err)
+		}
+		delete(s.refcount, hash)
+	}
+
+	if !dryRun && len(report.OrphanedChunks) > 0 {
+		if err := s.saveRefcounts(); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// VerifyDeep re-hashes every chunk on disk against its filename and removes
+// any chunk not present in referenced - the set of hashes every surviving
+// version's manifest cites, as gathered by Manager.VerifyDeep. A corrupt
+// chunk (content no longer matches its digest) is reported but left in
+// place, since deleting it would silently destroy data a version still
+// depends on; only genuinely unreferenced chunks are pruned.
+func (s *Store) VerifyDeep(referenced map[string]bool) (VerifyReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var report VerifyReport
+
+	for hash := range s.refcount {
+		path := s.chunkPath(hash)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				report.MissingChunks = append(report.MissingChunks, hash)
+				continue
+			}
+			return report, fmt.Errorf("failed to read chunk %s: %w", hash, err)
+		}
+		report.ChunksChecked++
+
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != hash {
+			report.CorruptChunks = append(report.CorruptChunks, hash)
+			continue
+		}
+
+		if !referenced[hash] {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return report, fmt.Errorf("failed to remove orphaned chunk %s: %w", hash, err)
+			}
+			report.OrphanedChunks = append(report.OrphanedChunks, hash)
+			report.BytesReclaimed += int64(len(data))
+			delete(s.refcount, hash)
+		}
+	}
+
+	if len(report.OrphanedChunks) > 0 {
+		if err := s.saveRefcounts(); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
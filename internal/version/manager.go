@@ -3,6 +3,7 @@ package version
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,46 +12,114 @@ import (
 	"time"
 
 	"github.com/jack-sneddon/backup-butler/internal/config"
+	"github.com/jack-sneddon/backup-butler/internal/storage/backend"
+	"github.com/jack-sneddon/backup-butler/internal/storage/repository"
 	"github.com/jack-sneddon/backup-butler/internal/types"
+	"github.com/jack-sneddon/backup-butler/internal/version/chunkstore"
+)
+
+// versionsPrefix and indexPath are the repository-relative locations the
+// index and version files live at - baseDir/.versions and
+// baseDir/.versions/backups for a local target, the equivalent prefix
+// under the SFTP or S3 root for a remote one.
+const (
+	versionsPrefix = ".versions/backups/"
+	indexPath      = ".versions/index.json"
 )
 
 type Manager struct {
 	baseDir     string
+	repo        repository.Repository
 	index       *FileIndex
 	indexLock   sync.RWMutex
 	currentVer  *BackupVersion
 	maxVersions int // For version retention
+	chunks      *chunkstore.Store
 }
 
-func NewManager(baseDir string) (*Manager, error) {
+// NewManager opens the version store at target - a local path, or an
+// sftp:// / s3:// URL - selecting the matching repository.Repository so
+// loadIndex, saveIndex, CompleteVersion, and cleanupOldVersions all go
+// through the same backend regardless of where the target actually lives.
+// The chunk store itself stays local-only (chunkstore.Open always takes a
+// filesystem path): chunking relies on direct, sharded file access that
+// doesn't map cleanly onto a remote repository, so for now it's rooted
+// under baseDir even when target is remote.
+func NewManager(target string, bufferSize int) (*Manager, error) {
+	repo, err := backend.Open(target, bufferSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open version store %q: %w", target, err)
+	}
+
 	m := &Manager{
-		baseDir:     baseDir,
+		baseDir:     target,
+		repo:        repo,
 		maxVersions: 30, // Keep last 30 versions by default
 	}
 
-	// Create version directory structure
-	dirs := []string{
-		filepath.Join(baseDir, ".versions"),
-		filepath.Join(baseDir, ".versions", "backups"),
+	// Load or create index
+	if err := m.loadIndex(); err != nil {
+		return nil, err
 	}
 
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
+	chunks, err := chunkstore.Open(target)
+	if err != nil {
+		return nil, err
 	}
+	m.chunks = chunks
 
-	// Load or create index
-	if err := m.loadIndex(); err != nil {
+	return m, nil
+}
+
+// ChunkFile splits data into content-defined chunks and stores any new ones
+// in the content-addressed chunk store, returning the manifest RecordFile
+// should be given for this file.
+func (m *Manager) ChunkFile(data []byte) ([]chunkstore.ChunkRef, error) {
+	return m.chunks.Put(data)
+}
+
+// ReuseChunks bumps the refcount of an existing chunk manifest for a file
+// that's being recorded again (skipped, unchanged) without re-chunking it.
+func (m *Manager) ReuseChunks(refs []chunkstore.ChunkRef) error {
+	return m.chunks.Reuse(refs)
+}
+
+// ChunksForPath returns the chunk manifest recorded the last time path was
+// backed up, or nil if none is on file (e.g. the file predates chunking).
+func (m *Manager) ChunksForPath(path string) []chunkstore.ChunkRef {
+	m.indexLock.RLock()
+	defer m.indexLock.RUnlock()
+	return m.index.Files[path].Chunks
+}
+
+// RestoreFile reassembles path as it was recorded in versionID from the
+// chunk store, the inverse of RecordFile's chunk manifest.
+func (m *Manager) RestoreFile(versionID, path string) ([]byte, error) {
+	data, err := m.readVersionFile(versionID)
+	if err != nil {
 		return nil, err
 	}
 
-	return m, nil
+	var v BackupVersion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse version %s: %w", versionID, err)
+	}
+
+	for _, change := range v.Changes {
+		if change.Path != path {
+			continue
+		}
+		if len(change.Chunks) == 0 {
+			return nil, fmt.Errorf("version %s has no chunk manifest for %s", versionID, path)
+		}
+		return m.chunks.Reassemble(change.Chunks)
+	}
+
+	return nil, fmt.Errorf("version %s has no record of %s", versionID, path)
 }
 
 func (m *Manager) loadIndex() error {
-	indexPath := filepath.Join(m.baseDir, ".versions", "index.json")
-	data, err := os.ReadFile(indexPath)
+	reader, err := m.repo.OpenReader(indexPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			m.index = &FileIndex{
@@ -61,6 +130,12 @@ func (m *Manager) loadIndex() error {
 		}
 		return fmt.Errorf("failed to read index: %w", err)
 	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
 
 	m.index = &FileIndex{}
 	if err := json.Unmarshal(data, m.index); err != nil {
@@ -75,20 +150,21 @@ func (m *Manager) saveIndex() error {
 	defer m.indexLock.Unlock()
 
 	m.index.LastUpdated = time.Now()
-	indexPath := filepath.Join(m.baseDir, ".versions", "index.json")
-	tempPath := indexPath + ".tmp"
 
 	data, err := json.MarshalIndent(m.index, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal index: %w", err)
 	}
 
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary index: %w", err)
+	writer, err := m.repo.OpenWriter(indexPath, true)
+	if err != nil {
+		return fmt.Errorf("failed to open index for writing: %w", err)
 	}
-
-	if err := os.Rename(tempPath, indexPath); err != nil {
-		os.Remove(tempPath)
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	if err := writer.Close(); err != nil {
 		return fmt.Errorf("failed to save index: %w", err)
 	}
 
@@ -96,8 +172,15 @@ func (m *Manager) saveIndex() error {
 }
 
 func (m *Manager) StartNewVersion(config *config.Config) *BackupVersion {
+	return m.StartVersionWithID(config, time.Now().Format("20060102-150405"))
+}
+
+// StartVersionWithID starts a version using a caller-supplied ID instead of
+// deriving one from the current time, so a resumed session keeps writing to
+// the same version it was interrupted partway through.
+func (m *Manager) StartVersionWithID(config *config.Config, id string) *BackupVersion {
 	version := &BackupVersion{
-		ID:        time.Now().Format("20060102-150405"),
+		ID:        id,
 		StartTime: time.Now(),
 		Changes:   make([]FileChange, 0),
 	}
@@ -113,7 +196,7 @@ func (m *Manager) StartNewVersion(config *config.Config) *BackupVersion {
 }
 
 // internal/version/manager.go
-func (m *Manager) RecordFile(path string, status string, size int64, modTime time.Time, checksum string) error {
+func (m *Manager) RecordFile(path string, status string, size int64, modTime time.Time, checksum string, chunks []chunkstore.ChunkRef) error {
 	if m.currentVer == nil {
 		return fmt.Errorf("no backup version in progress")
 	}
@@ -124,6 +207,7 @@ func (m *Manager) RecordFile(path string, status string, size int64, modTime tim
 		Action:    status,
 		Size:      size,
 		Timestamp: time.Now(),
+		Chunks:    chunks,
 	}
 	if status == "copied" {
 		change.Checksum = checksum
@@ -170,12 +254,20 @@ func (m *Manager) RecordFile(path string, status string, size int64, modTime tim
 	}
 
 	// Update file index
+	var params *chunkstore.Params
+	if len(chunks) > 0 {
+		p := chunkstore.CurrentParams()
+		params = &p
+	}
+
 	m.indexLock.Lock()
 	m.index.Files[path] = FileMetadata{
 		LastBackupID: m.currentVer.ID,
 		Size:         size,
 		ModTime:      modTime,
 		Checksum:     checksum,
+		Chunks:       chunks,
+		ChunkParams:  params,
 	}
 	m.indexLock.Unlock()
 
@@ -189,14 +281,20 @@ func (m *Manager) CompleteVersion() error {
 
 	m.currentVer.EndTime = time.Now()
 
-	// Save version file
-	versionFile := filepath.Join(m.baseDir, ".versions", "backups", m.currentVer.ID+".json")
 	data, err := json.MarshalIndent(m.currentVer, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal version data: %w", err)
 	}
 
-	if err := os.WriteFile(versionFile, data, 0644); err != nil {
+	writer, err := m.repo.OpenWriter(versionsPrefix+m.currentVer.ID+".json", true)
+	if err != nil {
+		return fmt.Errorf("failed to open version file for writing: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write version file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
 		return fmt.Errorf("failed to save version file: %w", err)
 	}
 
@@ -209,17 +307,32 @@ func (m *Manager) CompleteVersion() error {
 	return nil
 }
 
+// readVersionFile reads a version file's raw JSON through the repository,
+// given just its ID.
+func (m *Manager) readVersionFile(versionID string) ([]byte, error) {
+	reader, err := m.repo.OpenReader(versionsPrefix + versionID + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version %s: %w", versionID, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version %s: %w", versionID, err)
+	}
+	return data, nil
+}
+
 func (m *Manager) cleanupOldVersions() error {
-	backupsDir := filepath.Join(m.baseDir, ".versions", "backups")
-	entries, err := os.ReadDir(backupsDir)
+	entries, err := m.repo.List(versionsPrefix)
 	if err != nil {
-		return fmt.Errorf("failed to read backups directory: %w", err)
+		return fmt.Errorf("failed to list backups: %w", err)
 	}
 
 	var versions []string
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
-			versions = append(versions, entry.Name())
+		if strings.HasSuffix(entry, ".json") {
+			versions = append(versions, entry)
 		}
 	}
 
@@ -229,7 +342,10 @@ func (m *Manager) cleanupOldVersions() error {
 	// Remove excess versions
 	if len(versions) > m.maxVersions {
 		for _, v := range versions[m.maxVersions:] {
-			if err := os.Remove(filepath.Join(backupsDir, v)); err != nil {
+			if err := m.releaseVersionChunks(v); err != nil {
+				return fmt.Errorf("failed to release chunks for old version %s: %w", v, err)
+			}
+			if err := m.repo.Remove(v); err != nil {
 				return fmt.Errorf("failed to remove old version %s: %w", v, err)
 			}
 		}
@@ -238,27 +354,54 @@ func (m *Manager) cleanupOldVersions() error {
 	return nil
 }
 
-func (m *Manager) GetVersions() ([]VersionSummary, error) {
-	backupsDir := filepath.Join(m.baseDir, ".versions", "backups")
-	entries, err := os.ReadDir(backupsDir)
+// releaseVersionChunks decrements the chunk store refcount for every chunk
+// a version's manifest cites, freeing any that drop to zero references, so
+// removing a version file doesn't silently orphan the chunks it alone kept
+// alive. versionPath is a repository-relative path, as returned by
+// m.repo.List.
+func (m *Manager) releaseVersionChunks(versionPath string) error {
+	reader, err := m.repo.OpenReader(versionPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
+		return fmt.Errorf("failed to read version file %s: %w", versionPath, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read version file %s: %w", versionPath, err)
+	}
+
+	var v BackupVersion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("failed to parse version file %s: %w", versionPath, err)
+	}
+
+	for _, change := range v.Changes {
+		if err := m.chunks.Release(change.Chunks); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to read backups directory: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) GetVersions() ([]VersionSummary, error) {
+	entries, err := m.repo.List(versionsPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
 	}
 
 	var summaries []VersionSummary
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
-			data, err := os.ReadFile(filepath.Join(backupsDir, entry.Name()))
+		if strings.HasSuffix(entry, ".json") {
+			data, err := m.readVersionFile(strings.TrimSuffix(filepath.Base(entry), ".json"))
 			if err != nil {
-				return nil, fmt.Errorf("failed to read version file %s: %w", entry.Name(), err)
+				return nil, err
 			}
 
 			var version BackupVersion
 			if err := json.Unmarshal(data, &version); err != nil {
-				return nil, fmt.Errorf("failed to parse version file %s: %w", entry.Name(), err)
+				return nil, fmt.Errorf("failed to parse version file %s: %w", entry, err)
 			}
 
 			summary := VersionSummary{
@@ -267,6 +410,7 @@ func (m *Manager) GetVersions() ([]VersionSummary, error) {
 				EndTime:   version.EndTime,
 				Stats:     version.Stats.Total,
 				DirStats:  version.Stats.Directories,
+				Tags:      version.Tags,
 			}
 			summaries = append(summaries, summary)
 		}
@@ -280,6 +424,132 @@ func (m *Manager) GetVersions() ([]VersionSummary, error) {
 	return summaries, nil
 }
 
+// LatestVersionForSide returns the most recently started BackupVersion
+// tagged with side (see bisync, the only caller that sets Side), or nil
+// if none exists yet - the case bisync treats as "never run before" and
+// handles the same way --resync does.
+func (m *Manager) LatestVersionForSide(side string) (*BackupVersion, error) {
+	entries, err := m.repo.List(versionsPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var latest *BackupVersion
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, ".json") {
+			continue
+		}
+
+		data, err := m.readVersionFile(strings.TrimSuffix(filepath.Base(entry), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		var version BackupVersion
+		if err := json.Unmarshal(data, &version); err != nil {
+			return nil, fmt.Errorf("failed to parse version file %s: %w", entry, err)
+		}
+		if version.Side != side {
+			continue
+		}
+		if latest == nil || version.StartTime.After(latest.StartTime) {
+			v := version
+			latest = &v
+		}
+	}
+
+	return latest, nil
+}
+
+// VerifyDeep re-hashes every entry in the chunk store against every
+// surviving version's manifest, reporting (and for orphans, removing) any
+// chunk whose content no longer matches its digest or that no version
+// references anymore. It's the chunk-store equivalent of Prune's GC pass,
+// except it also catches silent on-disk corruption rather than just
+// reclaiming space.
+func (m *Manager) VerifyDeep() (chunkstore.VerifyReport, error) {
+	entries, err := m.repo.List(versionsPrefix)
+	if err != nil {
+		return chunkstore.VerifyReport{}, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, ".json") {
+			continue
+		}
+
+		data, err := m.readVersionFile(strings.TrimSuffix(filepath.Base(entry), ".json"))
+		if err != nil {
+			return chunkstore.VerifyReport{}, err
+		}
+
+		var v BackupVersion
+		if err := json.Unmarshal(data, &v); err != nil {
+			return chunkstore.VerifyReport{}, fmt.Errorf("failed to parse version file %s: %w", entry, err)
+		}
+
+		for _, change := range v.Changes {
+			for _, ref := range change.Chunks {
+				referenced[ref.Hash] = true
+			}
+		}
+	}
+
+	return m.chunks.VerifyDeep(referenced)
+}
+
+// GC reclaims chunk store space by reference count alone, without
+// VerifyDeep's re-hash of every surviving chunk. If keepRecent is 0, the
+// live set is built from every version manifest, the same conservative
+// computation VerifyDeep uses - a chunk is only removed if no surviving
+// version references it. If keepRecent is positive, only the keepRecent
+// most recently started versions contribute to the live set; chunks
+// exclusively referenced by older versions become eligible for removal too,
+// even though those versions' manifest files are still on disk and would
+// then fail to reassemble the files they touched. That tradeoff is exactly
+// why keepRecent defaults to 0 (fully safe) and must be opted into
+// explicitly. dryRun reports what GC would remove without removing it.
+func (m *Manager) GC(keepRecent int, dryRun bool) (chunkstore.VerifyReport, error) {
+	entries, err := m.repo.List(versionsPrefix)
+	if err != nil {
+		return chunkstore.VerifyReport{}, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, ".json") {
+			versions = append(versions, entry)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+	if keepRecent > 0 && keepRecent < len(versions) {
+		versions = versions[:keepRecent]
+	}
+
+	referenced := make(map[string]bool)
+	for _, entry := range versions {
+		data, err := m.readVersionFile(strings.TrimSuffix(filepath.Base(entry), ".json"))
+		if err != nil {
+			return chunkstore.VerifyReport{}, err
+		}
+
+		var v BackupVersion
+		if err := json.Unmarshal(data, &v); err != nil {
+			return chunkstore.VerifyReport{}, fmt.Errorf("failed to parse version file %s: %w", entry, err)
+		}
+
+		for _, change := range v.Changes {
+			for _, ref := range change.Chunks {
+				referenced[ref.Hash] = true
+			}
+		}
+	}
+
+	return m.chunks.GC(referenced, dryRun)
+}
+
 // internal/version/manager.go
 func (m *Manager) GetFileLastVersion(path string) (*types.FileVersionInfo, error) {
 	m.indexLock.RLock()
@@ -0,0 +1,310 @@
+// internal/version/prune.go
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PruneOptions controls which versions GC removes from the history journal.
+// The keep policies compose as a union: a version is retained if any policy
+// that is set keeps it. KeepStorage is applied last: walking from the
+// oldest version, it removes versions no other policy keeps until the total
+// footprint is at or below the cap, and keeps every version after that.
+type PruneOptions struct {
+	KeepLast    int           // keep the N most recent versions regardless of age
+	KeepWithin  time.Duration // keep versions newer than this duration
+	KeepStorage int64         // delete oldest versions until footprint is <= this many bytes
+
+	// Grandfather-style buckets, modeled on restic's forget policy: each
+	// keeps the newest version representing a still-unfilled time bucket
+	// (hour/day/ISO week/month/year), up to the given count of buckets.
+	KeepHourly  int
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+	KeepYearly  int
+
+	KeepTags []string // keep any version tagged with one of these, regardless of age
+
+	DryRun bool // compute the report but do not delete anything
+}
+
+// PruneVersionResult describes what prune did (or would do) to a single version.
+type PruneVersionResult struct {
+	ID             string `json:"id"`
+	ReclaimedBytes int64  `json:"reclaimed_bytes"`
+	Removed        bool   `json:"removed"`
+	Reason         string `json:"reason"`
+}
+
+// PruneReport is the full result of a prune run, suitable for a dry-run
+// summary or for marshalling straight to JSON for scripting.
+type PruneReport struct {
+	DryRun         bool                 `json:"dry_run"`
+	Versions       []PruneVersionResult `json:"versions"`
+	TotalReclaimed int64                `json:"total_reclaimed_bytes"`
+	RemainingBytes int64                `json:"remaining_bytes"`
+}
+
+// versionFootprint pairs a version summary with the on-disk size of its
+// journal entry. Reported reclaimed bytes only count the journal file
+// itself; the chunks it references are shared with other versions via the
+// chunk store's refcounts and are reclaimed separately as they fall to zero
+// references, which may free more (or less) than the journal's own size.
+type versionFootprint struct {
+	summary VersionSummary
+	path    string
+	bytes   int64
+}
+
+// Prune evaluates the retention policies in opts against the stored version
+// history and deletes (or, in dry-run mode, reports on) the versions that
+// fall outside every retained window. A version is retained if it satisfies
+// KeepLast, KeepWithin, KeepTags or a Keep* bucket; KeepStorage then
+// removes the oldest versions none of those keeps, until the total journal
+// footprint drops at or below the cap.
+func (m *Manager) Prune(opts PruneOptions) (*PruneReport, error) {
+	footprints, err := m.versionFootprints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect version footprints: %w", err)
+	}
+
+	// Newest first, matching GetVersions.
+	sort.Slice(footprints, func(i, j int) bool {
+		return footprints[i].summary.StartTime.After(footprints[j].summary.StartTime)
+	})
+
+	keep := make([]bool, len(footprints))
+	now := time.Now()
+	for i, f := range footprints {
+		if opts.KeepLast > 0 && i < opts.KeepLast {
+			keep[i] = true
+			continue
+		}
+		if opts.KeepWithin > 0 && now.Sub(f.summary.StartTime) <= opts.KeepWithin {
+			keep[i] = true
+			continue
+		}
+		if hasTag(f.summary.Tags, opts.KeepTags) {
+			keep[i] = true
+		}
+	}
+
+	markGrandfatherBucket(footprints, keep, opts.KeepHourly, func(t time.Time) string {
+		return t.Format("2006010215")
+	})
+	markGrandfatherBucket(footprints, keep, opts.KeepDaily, func(t time.Time) string {
+		return t.Format("20060102")
+	})
+	markGrandfatherBucket(footprints, keep, opts.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	markGrandfatherBucket(footprints, keep, opts.KeepMonthly, func(t time.Time) string {
+		return t.Format("200601")
+	})
+	markGrandfatherBucket(footprints, keep, opts.KeepYearly, func(t time.Time) string {
+		return t.Format("2006")
+	})
+
+	// KeepStorage trims the oldest versions until the combined footprint
+	// drops to the cap, same as BuildKit's keep-storage: it never removes a
+	// version already protected by KeepLast/KeepWithin above, and once the
+	// budget is satisfied everything newer is kept too.
+	if opts.KeepStorage > 0 {
+		var total int64
+		for _, f := range footprints {
+			total += f.bytes
+		}
+		for i := len(footprints) - 1; i >= 0; i-- {
+			if total <= opts.KeepStorage || keep[i] {
+				keep[i] = true
+				continue
+			}
+			total -= footprints[i].bytes
+		}
+	}
+
+	report := &PruneReport{DryRun: opts.DryRun}
+	var remaining int64
+	for i, f := range footprints {
+		if keep[i] {
+			remaining += f.bytes
+			continue
+		}
+
+		result := PruneVersionResult{
+			ID:             f.summary.ID,
+			ReclaimedBytes: f.bytes,
+			Reason:         pruneReason(opts, i),
+		}
+
+		if !opts.DryRun {
+			if err := m.releaseVersionChunks(f.path); err != nil {
+				return nil, fmt.Errorf("failed to release chunks for version %s: %w", f.summary.ID, err)
+			}
+			if err := os.Remove(f.path); err != nil {
+				return nil, fmt.Errorf("failed to remove version %s: %w", f.summary.ID, err)
+			}
+			if err := m.removeFromIndex(f.summary.ID); err != nil {
+				return nil, fmt.Errorf("failed to update index after removing version %s: %w", f.summary.ID, err)
+			}
+			result.Removed = true
+		}
+
+		report.Versions = append(report.Versions, result)
+		report.TotalReclaimed += f.bytes
+	}
+	report.RemainingBytes = remaining
+
+	return report, nil
+}
+
+// markGrandfatherBucket keeps the newest version representing each distinct
+// bucket key, up to limit buckets, across the full newest-first list -
+// restic's forget semantics for a single --keep-hourly/daily/weekly/monthly/
+// yearly rule.
+func markGrandfatherBucket(footprints []versionFootprint, keep []bool, limit int, key func(time.Time) string) {
+	if limit <= 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, f := range footprints {
+		if len(seen) >= limit {
+			return
+		}
+		bucket := key(f.summary.StartTime)
+		if seen[bucket] {
+			continue
+		}
+		seen[bucket] = true
+		keep[i] = true
+	}
+}
+
+func hasTag(versionTags, keepTags []string) bool {
+	for _, vt := range versionTags {
+		for _, kt := range keepTags {
+			if vt == kt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func pruneReason(opts PruneOptions, index int) string {
+	switch {
+	case opts.KeepStorage > 0:
+		return "keep-storage cap exceeded"
+	case opts.KeepLast > 0:
+		return fmt.Sprintf("older than keep-last %d", opts.KeepLast)
+	case opts.KeepWithin > 0:
+		return fmt.Sprintf("older than keep-within %s", opts.KeepWithin)
+	default:
+		return "no retention policy matched"
+	}
+}
+
+func (m *Manager) versionFootprints() ([]versionFootprint, error) {
+	backupsDir := filepath.Join(m.baseDir, ".versions", "backups")
+	entries, err := os.ReadDir(backupsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backups directory: %w", err)
+	}
+
+	var footprints []versionFootprint
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(backupsDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat version file %s: %w", entry.Name(), err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read version file %s: %w", entry.Name(), err)
+		}
+
+		var v BackupVersion
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse version file %s: %w", entry.Name(), err)
+		}
+
+		footprints = append(footprints, versionFootprint{
+			summary: VersionSummary{
+				ID:        v.ID,
+				StartTime: v.StartTime,
+				EndTime:   v.EndTime,
+				Stats:     v.Stats.Total,
+				DirStats:  v.Stats.Directories,
+				Tags:      v.Tags,
+			},
+			path:  path,
+			bytes: info.Size(),
+		})
+	}
+
+	return footprints, nil
+}
+
+// removeFromIndex drops any file-index entries whose last backup was the
+// given version, so IntegrityCheck never reports a file as "last seen" in a
+// version that no longer exists.
+func (m *Manager) removeFromIndex(versionID string) error {
+	m.indexLock.Lock()
+	for path, meta := range m.index.Files {
+		if meta.LastBackupID == versionID {
+			delete(m.index.Files, path)
+		}
+	}
+	m.indexLock.Unlock()
+
+	return m.saveIndex()
+}
+
+// ParseKeepWithin parses durations like "30d" or "2w" in addition to
+// everything time.ParseDuration already accepts, since retention windows are
+// usually expressed in days/weeks rather than hours.
+func ParseKeepWithin(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	unit := s[len(s)-1]
+	var multiplier time.Duration
+	switch unit {
+	case 'd':
+		multiplier = 24 * time.Hour
+	case 'w':
+		multiplier = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid keep-within duration %q", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid keep-within duration %q", s)
+	}
+
+	return time.Duration(n) * multiplier, nil
+}
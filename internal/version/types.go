@@ -3,6 +3,8 @@ package version
 
 import (
 	"time"
+
+	"github.com/jack-sneddon/backup-butler/internal/version/chunkstore"
 )
 
 type FileIndex struct {
@@ -11,10 +13,12 @@ type FileIndex struct {
 }
 
 type FileMetadata struct {
-	LastBackupID string    `json:"last_backup_id"`
-	Size         int64     `json:"size"`
-	ModTime      time.Time `json:"mod_time"`
-	Checksum     string    `json:"checksum"`
+	LastBackupID string                `json:"last_backup_id"`
+	Size         int64                 `json:"size"`
+	ModTime      time.Time             `json:"mod_time"`
+	Checksum     string                `json:"checksum"`
+	Chunks       []chunkstore.ChunkRef `json:"chunks,omitempty"`       // content-addressed manifest, reused across versions when the file is unchanged
+	ChunkParams  *chunkstore.Params    `json:"chunk_params,omitempty"` // sizing Chunks was cut under; nil for files with no manifest
 }
 
 type BackupVersion struct {
@@ -26,14 +30,22 @@ type BackupVersion struct {
 		Total       BackupStats               `json:"total"`
 	} `json:"stats"`
 	Changes []FileChange `json:"changes"`
+	Tags    []string     `json:"tags,omitempty"`
+
+	// Side names which half of a bisync pair this version is a snapshot
+	// of (e.g. "path1"/"path2"), so both sides' histories can share the
+	// same .versions directory without one run's index overwriting the
+	// other's. Empty for an ordinary one-directional backup version.
+	Side string `json:"side,omitempty"`
 }
 
 type FileChange struct {
-	Path      string    `json:"path"`
-	Action    string    `json:"action"` // "copied", "skipped", "failed"
-	Size      int64     `json:"size"`
-	Timestamp time.Time `json:"timestamp"`
-	Checksum  string    `json:"checksum,omitempty"` // Only for copied files
+	Path      string                `json:"path"`
+	Action    string                `json:"action"` // "copied", "skipped", "failed"
+	Size      int64                 `json:"size"`
+	Timestamp time.Time             `json:"timestamp"`
+	Checksum  string                `json:"checksum,omitempty"` // Only for copied files
+	Chunks    []chunkstore.ChunkRef `json:"chunks,omitempty"`   // content-addressed manifest; empty for "failed"
 }
 
 type DirectoryStats struct {
@@ -62,4 +74,5 @@ type VersionSummary struct {
 	EndTime   time.Time                 `json:"end_time"`
 	Stats     BackupStats               `json:"stats"`
 	DirStats  map[string]DirectoryStats `json:"dir_stats"`
+	Tags      []string                  `json:"tags,omitempty"`
 }